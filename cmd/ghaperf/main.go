@@ -32,6 +32,7 @@ func core() int {
 	}
 	if err := cli.Run(ctx, logger, logLevel, &controller.Arg{
 		Getenv:  os.Getenv,
+		Stdin:   os.Stdin,
 		Stdout:  os.Stdout,
 		Stderr:  os.Stderr,
 		Fs:      afero.NewOsFs(),
@@ -0,0 +1,111 @@
+package cache
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+const (
+	filePermission = 0o644
+	// tempInfix is a part of temporary file names.
+	// Temporary files are left if ghaperf is interrupted, and ghaperf cache verify finds them.
+	tempInfix = ".tmp-"
+)
+
+// File is a cache file which is written atomically.
+// Data is written to a temporary file in the same directory, and the temporary file is renamed to the path by Commit.
+// So other processes never read half-written files.
+type File struct {
+	fs        afero.Fs
+	path      string
+	file      afero.File
+	gz        *gzip.Writer
+	w         io.Writer
+	committed bool
+}
+
+// CreateFile creates a file which is written atomically.
+// Close must be called to remove the temporary file if Commit isn't called.
+func CreateFile(afs afero.Fs, path string) (*File, error) {
+	f, err := afero.TempFile(afs, filepath.Dir(path), filepath.Base(path)+tempInfix+"*")
+	if err != nil {
+		return nil, fmt.Errorf("create a temporary file: %w", slogerr.With(err, "path", path))
+	}
+	return &File{
+		fs:   afs,
+		path: path,
+		file: f,
+		w:    f,
+	}, nil
+}
+
+// Create creates a compressed file which is written atomically.
+func Create(afs afero.Fs, path string) (*File, error) {
+	f, err := CreateFile(afs, path)
+	if err != nil {
+		return nil, err
+	}
+	f.gz = gzip.NewWriter(f.file)
+	f.w = f.gz
+	return f, nil
+}
+
+func (f *File) Write(p []byte) (int, error) {
+	return f.w.Write(p) //nolint:wrapcheck
+}
+
+// Commit flushes data and renames the temporary file to the path.
+func (f *File) Commit() error {
+	if f.gz != nil {
+		if err := f.gz.Close(); err != nil {
+			return fmt.Errorf("flush a compressed file: %w", slogerr.With(err, "path", f.path))
+		}
+	}
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("close a temporary file: %w", slogerr.With(err, "path", f.path))
+	}
+	if err := f.fs.Chmod(f.file.Name(), filePermission); err != nil {
+		return fmt.Errorf("change the permission of a temporary file: %w", slogerr.With(err, "path", f.path))
+	}
+	if err := f.fs.Rename(f.file.Name(), f.path); err != nil {
+		return fmt.Errorf("rename a temporary file: %w", slogerr.With(err, "path", f.path))
+	}
+	f.committed = true
+	return nil
+}
+
+// Close removes the temporary file if Commit isn't called or fails.
+func (f *File) Close() error {
+	if f.committed {
+		return nil
+	}
+	f.file.Close()
+	if err := f.fs.Remove(f.file.Name()); err != nil {
+		return fmt.Errorf("remove a temporary file: %w", slogerr.With(err, "path", f.file.Name()))
+	}
+	return nil
+}
+
+// WriteFile writes data to the file atomically.
+func WriteFile(afs afero.Fs, path string, data []byte) error {
+	f, err := CreateFile(afs, path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := f.Write(data); err != nil {
+		return fmt.Errorf("write a temporary file: %w", slogerr.With(err, "path", path))
+	}
+	return f.Commit()
+}
+
+// IsTempFile returns true if the file is a temporary file which isn't committed yet.
+func IsTempFile(name string) bool {
+	return strings.Contains(name, tempInfix)
+}
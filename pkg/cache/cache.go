@@ -0,0 +1,164 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/xdg"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// Cache manages cached workflow runs, jobs, and logs under the cache directory.
+//
+// The cache directory has the following layout.
+//
+//	runs/<owner>/<repo>/<run id>/<attempt>/run.json
+//	runs/<owner>/<repo>/<run id>/<attempt>/job_ids.json
+//	runs/<owner>/<repo>/<run id>/<attempt>/cached.txt
+//	runs/<owner>/<repo>/<run id>/<attempt>/log/*.txt.gz
+//	jobs/<owner>/<repo>/<job id>/job.json
+//	jobs/<owner>/<repo>/<job id>/log.txt.gz
+//	index/<owner>/<repo>/<workflow>/<filters hash>.json
+type Cache struct {
+	fs  afero.Fs
+	dir string
+	now func() time.Time
+}
+
+func New(fs afero.Fs, dir string) *Cache {
+	return &Cache{
+		fs:  fs,
+		dir: dir,
+		now: time.Now,
+	}
+}
+
+// Filter narrows down repositories.
+// If RepoOwner and RepoName are empty, all repositories are included.
+type Filter struct {
+	RepoOwner string
+	RepoName  string
+}
+
+type Repo struct {
+	Owner string
+	Name  string
+}
+
+func (r *Repo) String() string {
+	return r.Owner + "/" + r.Name
+}
+
+// listRepos returns repositories which have cached workflow runs or jobs.
+func (c *Cache) listRepos(filter *Filter) ([]*Repo, error) {
+	if filter.RepoOwner != "" {
+		return []*Repo{{Owner: filter.RepoOwner, Name: filter.RepoName}}, nil
+	}
+	repos := []*Repo{}
+	found := map[string]struct{}{}
+	for _, kind := range []string{"runs", "jobs"} {
+		kindDir := filepath.Join(c.dir, kind)
+		owners, err := readDirNames(c.fs, kindDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, owner := range owners {
+			names, err := readDirNames(c.fs, filepath.Join(kindDir, owner))
+			if err != nil {
+				return nil, err
+			}
+			for _, name := range names {
+				repo := &Repo{Owner: owner, Name: name}
+				if _, ok := found[repo.String()]; ok {
+					continue
+				}
+				found[repo.String()] = struct{}{}
+				repos = append(repos, repo)
+			}
+		}
+	}
+	return repos, nil
+}
+
+// Clear removes cached data of the repository.
+// If the filter is empty, the whole cache directory is removed.
+func (c *Cache) Clear(filter *Filter) error {
+	if filter.RepoOwner == "" {
+		if err := c.fs.RemoveAll(c.dir); err != nil {
+			return fmt.Errorf("remove the cache directory: %w", slogerr.With(err, "dir", c.dir))
+		}
+		return nil
+	}
+	for _, dir := range repoDirs(c.dir, &Repo{Owner: filter.RepoOwner, Name: filter.RepoName}) {
+		if err := c.fs.RemoveAll(dir); err != nil {
+			return fmt.Errorf("remove a cache directory: %w", slogerr.With(err, "dir", dir))
+		}
+	}
+	return nil
+}
+
+// repoDirs returns directories of cached workflow runs, jobs, and indexes of workflow runs of the repository.
+func repoDirs(cacheDir string, repo *Repo) []string {
+	return []string{
+		xdg.RunsCacheDir(cacheDir, repo.Owner, repo.Name),
+		xdg.JobsCacheDir(cacheDir, repo.Owner, repo.Name),
+		xdg.IndexCacheDir(cacheDir, repo.Owner, repo.Name),
+	}
+}
+
+// diskUsage returns the total size of files in the directory and the latest modification time of them.
+func diskUsage(afs afero.Fs, dir string) (int64, time.Time, error) {
+	var size int64
+	var modTime time.Time
+	if err := afero.Walk(afs, dir, func(_ string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		size += info.Size()
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+		return nil
+	}); err != nil {
+		return 0, time.Time{}, fmt.Errorf("walk a cache directory: %w", slogerr.With(err, "dir", dir))
+	}
+	return size, modTime, nil
+}
+
+func readDirNames(afs afero.Fs, dir string) ([]string, error) {
+	infos, err := afero.ReadDir(afs, dir)
+	if err != nil {
+		if errors.Is(err, afero.ErrFileNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read a cache directory: %w", slogerr.With(err, "dir", dir))
+	}
+	names := make([]string, 0, len(infos))
+	for _, info := range infos {
+		if !info.IsDir() {
+			continue
+		}
+		names = append(names, info.Name())
+	}
+	return names, nil
+}
+
+// removeEmptyParents removes empty parent directories of the path up to the stop directory.
+func (c *Cache) removeEmptyParents(path, stop string) {
+	for dir := filepath.Dir(path); dir != stop && len(dir) > len(stop); dir = filepath.Dir(dir) {
+		empty, err := afero.IsEmpty(c.fs, dir)
+		if err != nil || !empty {
+			return
+		}
+		if err := c.fs.Remove(dir); err != nil {
+			return
+		}
+	}
+}
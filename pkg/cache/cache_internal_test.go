@@ -0,0 +1,367 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+func TestParseSize(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		s      string
+		exp    int64
+		hasErr bool
+	}{
+		{name: "bytes", s: "100", exp: 100},
+		{name: "B", s: "100B", exp: 100},
+		{name: "MB", s: "500MB", exp: 500 * 1024 * 1024},
+		{name: "G", s: "1.5g", exp: 1536 * 1024 * 1024},
+		{name: "invalid", s: "1XB", hasErr: true},
+		{name: "negative", s: "-1GB", hasErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			size, err := ParseSize(tt.s)
+			if err != nil {
+				if !tt.hasErr {
+					t.Fatal(err)
+				}
+				return
+			}
+			if tt.hasErr {
+				t.Fatal("error should be returned")
+			}
+			if size != tt.exp {
+				t.Errorf("ParseSize(%s) = %d, want %d", tt.s, size, tt.exp)
+			}
+		})
+	}
+}
+
+func newTestCache(t *testing.T, files map[string]string, modTimes map[string]time.Time) *Cache {
+	t.Helper()
+	fs := afero.NewMemMapFs()
+	for path, content := range files {
+		path = filepath.Join("/cache", path)
+		if err := fs.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := afero.WriteFile(fs, path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for path, modTime := range modTimes {
+		if err := fs.Chtimes(filepath.Join("/cache", path), modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return New(fs, "/cache")
+}
+
+func TestCache_Prune(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2025, 10, 29, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-48 * time.Hour)
+	c := newTestCache(t, map[string]string{
+		"runs/foo/bar/1/1/run.json":     "{}",
+		"runs/foo/bar/1/1/job_ids.json": "[10]",
+		"jobs/foo/bar/10/job.json":      "{}",
+		"jobs/foo/bar/20/job.json":      "{}",
+		"runs/foo/bar/2/1/run.json":     "{}",
+	}, map[string]time.Time{
+		"runs/foo/bar/1/1/run.json":     old,
+		"runs/foo/bar/1/1/job_ids.json": old,
+		"jobs/foo/bar/10/job.json":      old,
+		"jobs/foo/bar/20/job.json":      old,
+		"runs/foo/bar/2/1/run.json":     now,
+	})
+	c.now = func() time.Time { return now }
+	removed, err := c.Prune(&Filter{}, &PruneOptions{OlderThan: 24 * time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(removed) != 2 {
+		t.Fatalf("the number of removed entries = %d, want 2", len(removed))
+	}
+	for path, exist := range map[string]bool{
+		"/cache/runs/foo/bar/1":   false,
+		"/cache/jobs/foo/bar/10":  false,
+		"/cache/jobs/foo/bar/20":  false,
+		"/cache/runs/foo/bar/2/1": true,
+	} {
+		f, err := afero.Exists(c.fs, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f != exist {
+			t.Errorf("%s exists = %v, want %v", path, f, exist)
+		}
+	}
+}
+
+func TestCache_Verify(t *testing.T) {
+	t.Parallel()
+	c := newTestCache(t, map[string]string{
+		"runs/foo/bar/1/1/run.json":       "{",
+		"runs/foo/bar/2/1/run.json":       "{}",
+		"runs/foo/bar/2/1/log/0_test.txt": "log",
+		"runs/foo/bar/3/1/run.json":       "{}",
+		"runs/foo/bar/3/1/job_ids.json":   "[30]",
+		"runs/foo/bar/4/1/run.json":       "{}",
+		"runs/foo/bar/4/1/job_ids.json":   "[40]",
+		"runs/foo/bar/4/1/cached.txt":     "",
+		"runs/foo/bar/4/1/log/0_test.txt": "log",
+		"jobs/foo/bar/40/job.json":        "{}",
+	}, nil)
+	issues, err := c.Verify(&Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := map[string]string{
+		"/cache/runs/foo/bar/1/1/run.json": "/cache/runs/foo/bar/1/1",
+		"/cache/runs/foo/bar/2/1/log":      "/cache/runs/foo/bar/2/1/log",
+		"/cache/jobs/foo/bar/30/job.json":  "/cache/runs/foo/bar/3/1",
+	}
+	if len(issues) != len(exp) {
+		t.Fatalf("the number of issues = %d, want %d", len(issues), len(exp))
+	}
+	for _, issue := range issues {
+		if exp[issue.Path] != issue.Remove {
+			t.Errorf("issue %s (%s) removes %s, want %s", issue.Path, issue.Message, issue.Remove, exp[issue.Path])
+		}
+	}
+}
+
+func TestCache_CompressAll(t *testing.T) {
+	t.Parallel()
+	content := strings.Repeat("2025-10-29T12:00:00.0000000Z hello\n", 100)
+	c := newTestCache(t, map[string]string{
+		"runs/foo/bar/1/1/run.json":       "{}",
+		"runs/foo/bar/1/1/cached.txt":     "",
+		"runs/foo/bar/1/1/log/0_test.txt": content,
+		"jobs/foo/bar/10/job.json":        "{}",
+		"jobs/foo/bar/10/log.txt":         content,
+	}, nil)
+	count, saved, err := c.CompressAll(&Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Errorf("the number of compressed files = %d, want 2", count)
+	}
+	if saved <= 0 {
+		t.Errorf("saved size = %d, want a positive number", saved)
+	}
+	for _, path := range []string{"/cache/runs/foo/bar/1/1/log/0_test.txt.gz", "/cache/jobs/foo/bar/10/log.txt.gz"} {
+		r, err := Open(c.fs, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != content {
+			t.Errorf("the decompressed content of %s is different from the original content", path)
+		}
+	}
+	if f, err := afero.Exists(c.fs, "/cache/jobs/foo/bar/10/log.txt"); err != nil || f {
+		t.Error("the uncompressed log file should be removed")
+	}
+	issues, err := c.Verify(&Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("compressed logs should be valid: %s", issues[0].Message)
+	}
+}
+
+func TestCreateFile(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	if err := fs.MkdirAll("/cache", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	// a file which isn't committed is removed
+	f, err := CreateFile(fs, "/cache/run.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte("{")); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	infos, err := afero.ReadDir(fs, "/cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 0 {
+		t.Fatalf("the temporary file %s should be removed", infos[0].Name())
+	}
+	if err := WriteFile(fs, "/cache/run.json", []byte("{}")); err != nil {
+		t.Fatal(err)
+	}
+	b, err := afero.ReadFile(fs, "/cache/run.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "{}" {
+		t.Errorf("content = %s, want {}", string(b))
+	}
+}
+
+func TestLock(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	unlock, err := Lock(t.Context(), fs, "/cache/log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(t.Context(), 2*lockRetryInterval)
+	defer cancel()
+	if _, err := Lock(ctx, fs, "/cache/log"); err == nil {
+		t.Fatal("the lock should be held by another process")
+	}
+	unlock()
+	unlock, err = Lock(t.Context(), fs, "/cache/log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a stale lock is taken over
+	stale := time.Now().Add(-2 * StaleLockAge)
+	if err := fs.Chtimes("/cache/log"+lockExt, stale, stale); err != nil {
+		t.Fatal(err)
+	}
+	unlockNew, err := Lock(t.Context(), fs, "/cache/log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the lock taken over isn't released by the previous owner
+	unlock()
+	if _, err := fs.Stat("/cache/log" + lockExt); err != nil {
+		t.Fatalf("the lock file should be kept: %v", err)
+	}
+	unlockNew()
+	if _, err := fs.Stat("/cache/log" + lockExt); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("the lock file should be removed: %v", err)
+	}
+}
+
+func TestHoldLock(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	lockPath := "/cache/log" + lockExt
+	if err := afero.WriteFile(fs, lockPath, []byte("1-1"), filePermission); err != nil {
+		t.Fatal(err)
+	}
+	stale := time.Now().Add(-2 * StaleLockAge)
+	if err := fs.Chtimes(lockPath, stale, stale); err != nil {
+		t.Fatal(err)
+	}
+	unlock := holdLock(fs, lockPath, "1-1", 10*time.Millisecond)
+	deadline := time.Now().Add(5 * time.Second)
+	for isStaleLock(fs, lockPath) {
+		if time.Now().After(deadline) {
+			t.Fatal("the modification time of the held lock should be refreshed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	unlock()
+	unlock()
+	if _, err := fs.Stat(lockPath); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("the lock file should be removed: %v", err)
+	}
+}
+
+func TestTakeOverStaleLock(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	lockPath := "/cache/log" + lockExt
+	stale := time.Now().Add(-2 * StaleLockAge)
+	if err := afero.WriteFile(fs, lockPath, []byte("old"), filePermission); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Chtimes(lockPath, stale, stale); err != nil {
+		t.Fatal(err)
+	}
+	// both waiters find the same stale lock
+	ownerA, okA := staleLockOwner(fs, lockPath)
+	ownerB, okB := staleLockOwner(fs, lockPath)
+	if !okA || !okB || ownerA != "old" || ownerB != "old" {
+		t.Fatalf("the lock should be stale: (%q, %v), (%q, %v)", ownerA, okA, ownerB, okB)
+	}
+	// the waiter A takes over the stale lock and creates a new lock
+	if !takeOverStaleLock(fs, lockPath, ownerA) {
+		t.Fatal("the stale lock should be taken over")
+	}
+	if err := afero.WriteFile(fs, lockPath, []byte("a"), filePermission); err != nil {
+		t.Fatal(err)
+	}
+	// the waiter B must not remove the new lock
+	if takeOverStaleLock(fs, lockPath, ownerB) {
+		t.Fatal("the new lock shouldn't be taken over")
+	}
+	b, err := afero.ReadFile(fs, lockPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "a" {
+		t.Errorf("the lock owner = %q, want a", b)
+	}
+	if _, err := fs.Stat(lockPath + takeoverExt); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("the takeover guard should be removed: %v", err)
+	}
+}
+
+func TestLock_concurrentTakeover(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	lockPath := "/cache/log" + lockExt
+	stale := time.Now().Add(-2 * StaleLockAge)
+	if err := afero.WriteFile(fs, lockPath, []byte("old"), filePermission); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Chtimes(lockPath, stale, stale); err != nil {
+		t.Fatal(err)
+	}
+	var mu sync.Mutex
+	holders, maxHolders := 0, 0
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Go(func() {
+			unlock, err := Lock(t.Context(), fs, "/cache/log")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			mu.Lock()
+			holders++
+			maxHolders = max(maxHolders, holders)
+			mu.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			holders--
+			mu.Unlock()
+			unlock()
+		})
+	}
+	wg.Wait()
+	if maxHolders != 1 {
+		t.Errorf("the lock should be held by one waiter at a time: %d", maxHolders)
+	}
+}
@@ -0,0 +1,169 @@
+package cache
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// CompressedExt is the extension of compressed log files.
+// Logs are compressed by gzip because logs compress roughly 10x.
+const CompressedExt = ".gz"
+
+type compressedReader struct {
+	gz   *gzip.Reader
+	file afero.File
+}
+
+func (r *compressedReader) Read(p []byte) (int, error) {
+	return r.gz.Read(p) //nolint:wrapcheck
+}
+
+func (r *compressedReader) Close() error {
+	r.gz.Close()
+	return r.file.Close() //nolint:wrapcheck
+}
+
+// Open opens a cached log file.
+// Files with CompressedExt are decompressed transparently.
+func Open(afs afero.Fs, path string) (io.ReadCloser, error) {
+	f, err := afs.Open(path)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	if !strings.HasSuffix(path, CompressedExt) {
+		return f, nil
+	}
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("open a compressed file: %w", slogerr.With(err, "path", path))
+	}
+	return &compressedReader{
+		gz:   gz,
+		file: f,
+	}, nil
+}
+
+// Compress compresses an uncompressed log file cached by old ghaperf and removes the original file.
+// It returns the path to the compressed file.
+func Compress(afs afero.Fs, path string) (string, error) {
+	dest := path + CompressedExt
+	src, err := afs.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("open a log file: %w", slogerr.With(err, "path", path))
+	}
+	defer src.Close()
+	w, err := Create(afs, dest)
+	if err != nil {
+		return "", err
+	}
+	defer w.Close()
+	if _, err := io.Copy(w, src); err != nil {
+		return "", fmt.Errorf("compress a log file: %w", slogerr.With(err, "path", path))
+	}
+	if err := w.Commit(); err != nil {
+		return "", err
+	}
+	if err := afs.Remove(path); err != nil {
+		return "", fmt.Errorf("remove an uncompressed log file: %w", slogerr.With(err, "path", path))
+	}
+	return dest, nil
+}
+
+// IsCorrupt returns true if the error means that the compressed file is broken, e.g. the file is half-written.
+func IsCorrupt(err error) bool {
+	return errors.Is(err, gzip.ErrChecksum) || errors.Is(err, gzip.ErrHeader) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// isUncompressedLog returns true if the file is a log file cached by old ghaperf.
+// Job logs are log.txt and logs of workflow runs are files in log directories.
+func isUncompressedLog(path string) bool {
+	if strings.HasSuffix(path, CompressedExt) || IsTempFile(path) {
+		return false
+	}
+	return filepath.Base(path) == "log.txt" || filepath.Base(filepath.Dir(path)) == "log"
+}
+
+// CompressAll compresses all uncompressed log files.
+// It returns the number of compressed files and the reduced size.
+func (c *Cache) CompressAll(filter *Filter) (int, int64, error) {
+	repos, err := c.listRepos(filter)
+	if err != nil {
+		return 0, 0, err
+	}
+	count := 0
+	var saved int64
+	for _, repo := range repos {
+		for _, dir := range repoDirs(c.dir, repo) {
+			paths, err := c.listUncompressedLogs(dir)
+			if err != nil {
+				return count, saved, err
+			}
+			for _, path := range paths {
+				s, err := c.compress(path)
+				if err != nil {
+					return count, saved, err
+				}
+				count++
+				saved += s
+			}
+		}
+	}
+	return count, saved, nil
+}
+
+func (c *Cache) listUncompressedLogs(dir string) ([]string, error) {
+	paths := []string{}
+	if f, err := afero.DirExists(c.fs, dir); err != nil || !f {
+		return paths, nil //nolint:nilerr
+	}
+	if err := afero.Walk(c.fs, dir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && isUncompressedLog(path) {
+			paths = append(paths, path)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("walk a cache directory: %w", slogerr.With(err, "dir", dir))
+	}
+	return paths, nil
+}
+
+func (c *Cache) compress(path string) (int64, error) {
+	before, err := c.fs.Stat(path)
+	if err != nil {
+		return 0, fmt.Errorf("get a log file info: %w", slogerr.With(err, "path", path))
+	}
+	dest, err := Compress(c.fs, path)
+	if err != nil {
+		return 0, err
+	}
+	after, err := c.fs.Stat(dest)
+	if err != nil {
+		return 0, fmt.Errorf("get a compressed log file info: %w", slogerr.With(err, "path", dest))
+	}
+	return before.Size() - after.Size(), nil
+}
+
+// verifyCompressed returns a message if the compressed file is broken, e.g. the file is half-written.
+func (c *Cache) verifyCompressed(path string) string {
+	r, err := Open(c.fs, path)
+	if err != nil {
+		return "the compressed log can't be opened: " + err.Error()
+	}
+	defer r.Close()
+	if _, err := io.Copy(io.Discard, r); err != nil {
+		return "the compressed log is broken: " + err.Error()
+	}
+	return ""
+}
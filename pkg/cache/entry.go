@@ -0,0 +1,262 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/xdg"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+const (
+	KindRun = "run"
+	KindJob = "job"
+)
+
+// Entry is a unit of pruning.
+// A workflow run attempt includes its jobs, so pruning never breaks cached workflow runs.
+// Jobs which don't belong to any cached workflow run (e.g. jobs cached by --job-id) are entries by themselves.
+type Entry struct {
+	Repo    *Repo
+	Kind    string
+	Name    string
+	Paths   []string
+	Size    int64
+	ModTime time.Time
+}
+
+func (e *Entry) add(path string, size int64, modTime time.Time) {
+	e.Paths = append(e.Paths, path)
+	e.Size += size
+	if modTime.After(e.ModTime) {
+		e.ModTime = modTime
+	}
+}
+
+func (c *Cache) listEntries(filter *Filter) ([]*Entry, error) {
+	repos, err := c.listRepos(filter)
+	if err != nil {
+		return nil, err
+	}
+	entries := []*Entry{}
+	for _, repo := range repos {
+		arr, err := c.listRepoEntries(repo)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, arr...)
+	}
+	return entries, nil
+}
+
+func (c *Cache) listRepoEntries(repo *Repo) ([]*Entry, error) {
+	jobs, err := c.listJobEntries(repo)
+	if err != nil {
+		return nil, err
+	}
+	entries := []*Entry{}
+	runsDir := xdg.RunsCacheDir(c.dir, repo.Owner, repo.Name)
+	runIDs, err := readDirNames(c.fs, runsDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, runID := range runIDs {
+		attempts, err := readDirNames(c.fs, filepath.Join(runsDir, runID))
+		if err != nil {
+			return nil, err
+		}
+		for _, attempt := range attempts {
+			dir := filepath.Join(runsDir, runID, attempt)
+			size, modTime, err := diskUsage(c.fs, dir)
+			if err != nil {
+				return nil, err
+			}
+			entry := &Entry{
+				Repo: repo,
+				Kind: KindRun,
+				Name: runID + "/" + attempt,
+			}
+			entry.add(dir, size, modTime)
+			for _, jobID := range c.readJobIDs(filepath.Join(dir, "job_ids.json")) {
+				job, ok := jobs[jobID]
+				if !ok {
+					continue
+				}
+				entry.add(job.Paths[0], job.Size, job.ModTime)
+				delete(jobs, jobID)
+			}
+			entries = append(entries, entry)
+		}
+	}
+	for _, job := range jobs {
+		entries = append(entries, job)
+	}
+	return entries, nil
+}
+
+func (c *Cache) listJobEntries(repo *Repo) (map[int64]*Entry, error) {
+	jobsDir := xdg.JobsCacheDir(c.dir, repo.Owner, repo.Name)
+	jobIDs, err := readDirNames(c.fs, jobsDir)
+	if err != nil {
+		return nil, err
+	}
+	jobs := make(map[int64]*Entry, len(jobIDs))
+	for _, name := range jobIDs {
+		jobID, err := strconv.ParseInt(name, 10, 64)
+		if err != nil {
+			continue
+		}
+		dir := filepath.Join(jobsDir, name)
+		size, modTime, err := diskUsage(c.fs, dir)
+		if err != nil {
+			return nil, err
+		}
+		entry := &Entry{
+			Repo: repo,
+			Kind: KindJob,
+			Name: name,
+		}
+		entry.add(dir, size, modTime)
+		jobs[jobID] = entry
+	}
+	return jobs, nil
+}
+
+// readJobIDs returns job IDs of a cached workflow run.
+// Broken files are reported by Verify, so errors are ignored.
+func (c *Cache) readJobIDs(path string) []int64 {
+	b, err := afero.ReadFile(c.fs, path)
+	if err != nil {
+		return nil
+	}
+	jobIDs := []int64{}
+	if err := json.Unmarshal(b, &jobIDs); err != nil {
+		return nil
+	}
+	return jobIDs
+}
+
+type PruneOptions struct {
+	// OlderThan removes entries which haven't been updated for the duration
+	OlderThan time.Duration
+	// MaxSize removes the oldest entries until the total size is less than or equal to MaxSize
+	MaxSize int64
+	// DryRun returns entries which would be removed without removing them
+	DryRun bool
+}
+
+// Prune removes old entries and returns removed entries.
+func (c *Cache) Prune(filter *Filter, opts *PruneOptions) ([]*Entry, error) {
+	entries, err := c.listEntries(filter)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ModTime.Before(entries[j].ModTime)
+	})
+	var total int64
+	for _, entry := range entries {
+		total += entry.Size
+	}
+	var deadline time.Time
+	if opts.OlderThan > 0 {
+		deadline = c.now().Add(-opts.OlderThan)
+	}
+	removed := []*Entry{}
+	for _, entry := range entries {
+		old := !deadline.IsZero() && entry.ModTime.Before(deadline)
+		overSize := opts.MaxSize > 0 && total > opts.MaxSize
+		if !old && !overSize {
+			break
+		}
+		if !opts.DryRun {
+			if err := c.removeEntry(entry); err != nil {
+				return removed, err
+			}
+		}
+		total -= entry.Size
+		removed = append(removed, entry)
+	}
+	return removed, nil
+}
+
+func (c *Cache) removeEntry(entry *Entry) error {
+	for _, path := range entry.Paths {
+		if err := c.fs.RemoveAll(path); err != nil {
+			return fmt.Errorf("remove a cache directory: %w", slogerr.With(err, "dir", path))
+		}
+		c.removeEmptyParents(path, c.dir)
+	}
+	return nil
+}
+
+type Stat struct {
+	Repo         *Repo
+	Runs         int
+	Jobs         int
+	MetadataSize int64
+	LogSize      int64
+}
+
+func (s *Stat) Size() int64 {
+	return s.MetadataSize + s.LogSize
+}
+
+// Stats returns the number of cached workflow runs and jobs and the size of the cache per repository.
+// JSON files are metadata and other files are logs.
+func (c *Cache) Stats(filter *Filter) ([]*Stat, error) {
+	repos, err := c.listRepos(filter)
+	if err != nil {
+		return nil, err
+	}
+	stats := make([]*Stat, 0, len(repos))
+	for _, repo := range repos {
+		stat := &Stat{Repo: repo}
+		if err := c.walkStat(xdg.RunsCacheDir(c.dir, repo.Owner, repo.Name), stat, "run.json", &stat.Runs); err != nil {
+			return nil, err
+		}
+		if err := c.walkStat(xdg.JobsCacheDir(c.dir, repo.Owner, repo.Name), stat, "job.json", &stat.Jobs); err != nil {
+			return nil, err
+		}
+		stats = append(stats, stat)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Size() != stats[j].Size() {
+			return stats[i].Size() > stats[j].Size()
+		}
+		return stats[i].Repo.String() < stats[j].Repo.String()
+	})
+	return stats, nil
+}
+
+func (c *Cache) walkStat(dir string, stat *Stat, countedFile string, count *int) error {
+	if f, err := afero.DirExists(c.fs, dir); err != nil || !f {
+		return nil //nolint:nilerr
+	}
+	if err := afero.Walk(c.fs, dir, func(_ string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		if info.Name() == countedFile {
+			*count++
+		}
+		if filepath.Ext(info.Name()) == ".json" {
+			stat.MetadataSize += info.Size()
+			return nil
+		}
+		stat.LogSize += info.Size()
+		return nil
+	}); err != nil {
+		return fmt.Errorf("walk a cache directory: %w", slogerr.With(err, "dir", dir))
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+const (
+	lockExt = ".lock"
+	// takeoverExt is the extension of the file which guards the takeover of a stale lock.
+	takeoverExt = ".takeover"
+	// StaleLockAge is the age of lock files which are regarded as stale.
+	// Lock files are left if ghaperf is interrupted.
+	StaleLockAge      = 10 * time.Minute
+	lockRetryInterval = 500 * time.Millisecond
+	// lockHeartbeatInterval is the interval to refresh the modification time of held lock files so that they don't become stale.
+	lockHeartbeatInterval = StaleLockAge / 5
+	dirPermission         = 0o755
+)
+
+// Lock acquires a lock of the cache file or directory so that multiple ghaperf processes don't write the same cache at the same time.
+// The lock is a file created with O_EXCL.
+// If the lock is held by another process, Lock waits until the lock is released or becomes stale.
+// While the lock is held, the modification time of the lock file is refreshed so that the lock doesn't become stale.
+// The returned function releases the lock.
+// The lock file is removed only if it's still owned by the lock, because a stale lock may be taken over by another process.
+func Lock(ctx context.Context, afs afero.Fs, path string) (func(), error) {
+	lockPath := path + lockExt
+	if err := afs.MkdirAll(filepath.Dir(lockPath), dirPermission); err != nil {
+		return nil, fmt.Errorf("create a directory for a lock file: %w", slogerr.With(err, "path", lockPath))
+	}
+	// the process ID is written for debugging
+	owner := strconv.Itoa(os.Getpid()) + "-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	for {
+		f, err := afs.OpenFile(lockPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, filePermission)
+		if err == nil {
+			fmt.Fprint(f, owner)
+			f.Close()
+			return holdLock(afs, lockPath, owner, lockHeartbeatInterval), nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("create a lock file: %w", slogerr.With(err, "path", lockPath))
+		}
+		if staleOwner, ok := staleLockOwner(afs, lockPath); ok && takeOverStaleLock(afs, lockPath, staleOwner) {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("wait for a lock: %w", slogerr.With(ctx.Err(), "path", lockPath))
+		case <-time.After(lockRetryInterval):
+		}
+	}
+}
+
+func isStaleLock(afs afero.Fs, lockPath string) bool {
+	info, err := afs.Stat(lockPath)
+	if err != nil {
+		// the lock may be released
+		return false
+	}
+	return time.Since(info.ModTime()) > StaleLockAge
+}
+
+// staleLockOwner returns the owner of the lock file if the lock is stale.
+func staleLockOwner(afs afero.Fs, lockPath string) (string, bool) {
+	if !isStaleLock(afs, lockPath) {
+		return "", false
+	}
+	b, err := afero.ReadFile(afs, lockPath)
+	if err != nil {
+		// the lock may be released
+		return "", false
+	}
+	return string(b), true
+}
+
+// takeOverStaleLock removes the stale lock file and returns true if it's removed.
+// Removing a stale lock isn't atomic, so another process may take over the same stale lock and create a new lock file in the meantime.
+// To not remove the new lock file, only one process can take over at a time, and the lock file is checked again before it's removed.
+func takeOverStaleLock(afs afero.Fs, lockPath, staleOwner string) bool {
+	guardPath := lockPath + takeoverExt
+	f, err := afs.OpenFile(guardPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, filePermission)
+	if err != nil {
+		if isStaleLock(afs, guardPath) {
+			// the guard is left if a process is interrupted while taking over
+			afs.Remove(guardPath) //nolint:errcheck
+		}
+		return false
+	}
+	f.Close()
+	defer afs.Remove(guardPath) //nolint:errcheck
+	if owner, ok := staleLockOwner(afs, lockPath); !ok || owner != staleOwner {
+		return false
+	}
+	afs.Remove(lockPath) //nolint:errcheck
+	return true
+}
+
+// holdLock refreshes the modification time of the lock file at the interval until the returned function is called.
+// The returned function stops the refresh and removes the lock file if the lock file is owned by the owner.
+func holdLock(afs afero.Fs, lockPath, owner string, interval time.Duration) func() {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				now := time.Now()
+				afs.Chtimes(lockPath, now, now) //nolint:errcheck
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(stop)
+			<-done
+			b, err := afero.ReadFile(afs, lockPath)
+			if err != nil || string(b) != owner {
+				return
+			}
+			afs.Remove(lockPath) //nolint:errcheck
+		})
+	}
+}
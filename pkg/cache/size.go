@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+const sizeUnit = 1024
+
+var (
+	units          = []string{"B", "KB", "MB", "GB", "TB"} //nolint:gochecknoglobals
+	errInvalidSize = errors.New("invalid size. The size must be a number with an optional unit (B, KB, MB, GB, TB), e.g. 500MB")
+)
+
+// ParseSize parses a size like 500MB and 1.5G.
+// Units are powers of 1024.
+func ParseSize(s string) (int64, error) {
+	num := strings.ToUpper(strings.TrimSpace(s))
+	multiplier := 1.0
+	for i := len(units) - 1; i > 0; i-- {
+		if n, ok := trimUnit(num, units[i]); ok {
+			num = n
+			multiplier = math.Pow(sizeUnit, float64(i))
+			break
+		}
+	}
+	num = strings.TrimSpace(strings.TrimSuffix(num, "B"))
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil || f < 0 {
+		return 0, slogerr.With(errInvalidSize, "size", s) //nolint:wrapcheck
+	}
+	return int64(f * multiplier), nil
+}
+
+// trimUnit trims the unit (e.g. GB) or the abbreviated unit (e.g. G).
+func trimUnit(s, unit string) (string, bool) {
+	if n, ok := strings.CutSuffix(s, unit); ok {
+		return strings.TrimSpace(n), true
+	}
+	n, ok := strings.CutSuffix(s, strings.TrimSuffix(unit, "B"))
+	return strings.TrimSpace(n), ok
+}
+
+// FormatSize formats a size in bytes for humans.
+func FormatSize(size int64) string {
+	f := float64(size)
+	i := 0
+	for f >= sizeUnit && i < len(units)-1 {
+		f /= sizeUnit
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%d B", size)
+	}
+	return fmt.Sprintf("%.1f %s", f, units[i])
+}
@@ -0,0 +1,190 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/xdg"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// Issue is a broken cache entry.
+type Issue struct {
+	Path    string
+	Message string
+	// Remove is the path removed to fix the issue.
+	// ghaperf fetches data again if the cache doesn't exist.
+	Remove string
+}
+
+// Verify finds broken JSON files, half-written logs, and workflow runs whose jobs aren't cached.
+func (c *Cache) Verify(filter *Filter) ([]*Issue, error) {
+	repos, err := c.listRepos(filter)
+	if err != nil {
+		return nil, err
+	}
+	issues := []*Issue{}
+	for _, repo := range repos {
+		arr, err := c.verifyRuns(repo)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, arr...)
+		arr, err = c.verifyJobs(repo)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, arr...)
+	}
+	return issues, nil
+}
+
+// Fix removes broken cache entries.
+func (c *Cache) Fix(issues []*Issue) error {
+	for _, issue := range issues {
+		if err := c.fs.RemoveAll(issue.Remove); err != nil {
+			return fmt.Errorf("remove a broken cache: %w", slogerr.With(err, "path", issue.Remove))
+		}
+		c.removeEmptyParents(issue.Remove, c.dir)
+	}
+	return nil
+}
+
+func (c *Cache) verifyRuns(repo *Repo) ([]*Issue, error) {
+	runsDir := xdg.RunsCacheDir(c.dir, repo.Owner, repo.Name)
+	runIDs, err := readDirNames(c.fs, runsDir)
+	if err != nil {
+		return nil, err
+	}
+	issues := []*Issue{}
+	for _, runID := range runIDs {
+		attempts, err := readDirNames(c.fs, filepath.Join(runsDir, runID))
+		if err != nil {
+			return nil, err
+		}
+		for _, attempt := range attempts {
+			dir := filepath.Join(runsDir, runID, attempt)
+			if issue := c.verifyRun(repo, dir); issue != nil {
+				issues = append(issues, issue)
+				continue
+			}
+			issues = append(issues, c.verifyLeftovers(dir)...)
+			issues = append(issues, c.verifyLeftovers(filepath.Join(dir, "log"))...)
+		}
+	}
+	return issues, nil
+}
+
+func (c *Cache) verifyRun(repo *Repo, dir string) *Issue {
+	for _, name := range []string{"run.json", "job_ids.json"} {
+		path := filepath.Join(dir, name)
+		if msg := c.verifyJSON(path); msg != "" {
+			return &Issue{Path: path, Message: msg, Remove: dir}
+		}
+	}
+	logDir := filepath.Join(dir, "log")
+	if f, err := afero.DirExists(c.fs, logDir); err == nil && f {
+		// cached.txt is created after all log files are written
+		if f, err := afero.Exists(c.fs, filepath.Join(dir, "cached.txt")); err == nil && !f {
+			return &Issue{Path: logDir, Message: "logs are half-written", Remove: logDir}
+		}
+		if issue := c.verifyLogDir(logDir); issue != nil {
+			// remove the whole workflow run so that ghaperf downloads logs again
+			issue.Remove = dir
+			return issue
+		}
+	}
+	for _, jobID := range c.readJobIDs(filepath.Join(dir, "job_ids.json")) {
+		path := xdg.JobCache(c.dir, repo.Owner, repo.Name, jobID)
+		if f, err := afero.Exists(c.fs, path); err == nil && !f {
+			return &Issue{Path: path, Message: "the job of the workflow run isn't cached", Remove: dir}
+		}
+	}
+	return nil
+}
+
+func (c *Cache) verifyJobs(repo *Repo) ([]*Issue, error) {
+	jobsDir := xdg.JobsCacheDir(c.dir, repo.Owner, repo.Name)
+	jobIDs, err := readDirNames(c.fs, jobsDir)
+	if err != nil {
+		return nil, err
+	}
+	issues := []*Issue{}
+	for _, jobID := range jobIDs {
+		dir := filepath.Join(jobsDir, jobID)
+		path := filepath.Join(dir, "job.json")
+		if msg := c.verifyJSON(path); msg != "" {
+			issues = append(issues, &Issue{Path: path, Message: msg, Remove: dir})
+			continue
+		}
+		issues = append(issues, c.verifyLeftovers(dir)...)
+		logPath := filepath.Join(dir, "log.txt"+CompressedExt)
+		if f, err := afero.Exists(c.fs, logPath); err != nil || !f {
+			continue
+		}
+		if msg := c.verifyCompressed(logPath); msg != "" {
+			issues = append(issues, &Issue{Path: logPath, Message: msg, Remove: logPath})
+		}
+	}
+	return issues, nil
+}
+
+// verifyLeftovers finds temporary files and lock files left by interrupted ghaperf processes.
+// Files newer than StaleLockAge are ignored because other processes may be writing them.
+func (c *Cache) verifyLeftovers(dir string) []*Issue {
+	infos, err := afero.ReadDir(c.fs, dir)
+	if err != nil {
+		return nil
+	}
+	issues := []*Issue{}
+	for _, info := range infos {
+		if info.IsDir() || c.now().Sub(info.ModTime()) <= StaleLockAge {
+			continue
+		}
+		path := filepath.Join(dir, info.Name())
+		switch {
+		case IsTempFile(info.Name()):
+			issues = append(issues, &Issue{Path: path, Message: "the temporary file is left", Remove: path})
+		case strings.HasSuffix(info.Name(), lockExt):
+			issues = append(issues, &Issue{Path: path, Message: "the lock file is stale", Remove: path})
+		}
+	}
+	return issues
+}
+
+func (c *Cache) verifyLogDir(logDir string) *Issue {
+	infos, err := afero.ReadDir(c.fs, logDir)
+	if err != nil {
+		return &Issue{Path: logDir, Message: "the log directory can't be read: " + err.Error()}
+	}
+	for _, info := range infos {
+		if info.IsDir() || !strings.HasSuffix(info.Name(), CompressedExt) || IsTempFile(info.Name()) {
+			continue
+		}
+		path := filepath.Join(logDir, info.Name())
+		if msg := c.verifyCompressed(path); msg != "" {
+			return &Issue{Path: path, Message: msg}
+		}
+	}
+	return nil
+}
+
+// verifyJSON returns a message if the JSON file is broken.
+// Missing files aren't issues because the cache is optional.
+func (c *Cache) verifyJSON(path string) string {
+	b, err := afero.ReadFile(c.fs, path)
+	if err != nil {
+		if errors.Is(err, afero.ErrFileNotFound) {
+			return ""
+		}
+		return "the file can't be read: " + err.Error()
+	}
+	if !json.Valid(b) {
+		return "the JSON is broken"
+	}
+	return ""
+}
@@ -18,6 +18,14 @@ USAGE:
    ghaperf --help [-h] # Show this help
    ghaperf --version [-v] # Show version
    ghaperf [OPTIONS]
+   ghaperf serve [--addr <address>] [--config <path>] # Browse cached workflow runs in a web browser
+   ghaperf history --repo <owner>/<repo> [--job <job>] [--step <step>] [--group <group>] [--bucket <day|week>] [--since <duration>] # Show trends of recorded samples
+   ghaperf watch --repo <owner>/<repo> --run-id <run id> [--interval <duration>] # Watch a running workflow run and show the report when it completes
+   ghaperf cache stats [--repo <owner>/<repo>] # Show the size of the cache per repository
+   ghaperf cache prune [--repo <owner>/<repo>] [--older-than <duration>] [--max-size <size>] [--dry-run] # Remove old cache
+   ghaperf cache clear [--repo <owner>/<repo>] # Remove the cache
+   ghaperf cache verify [--repo <owner>/<repo>] [--fix] # Find broken JSON files and half-written logs in the cache
+   ghaperf cache compress [--repo <owner>/<repo>] # Compress log files cached by old ghaperf
 
 OPTIONS:
    --log-level <debug|info|warn|error>    Log level (or set GHIR_LOG_LEVEL)
@@ -26,7 +34,7 @@ OPTIONS:
    --job-id <job id>                      The job ID
    --attempt-number <attempt number>      The workflow run's attempt number
    --threshold <time duration>            The threshold duration (e.g., 30s, 1m)
-   --log-file <file path>                 Log file path
+   --log-file <file path>                 Log file path. A zip file of workflow run logs or a directory of job log files is also available. Glob patterns are available and this option can be repeated to aggregate durations of log groups. "-" reads a log or a log archive from the standard input
    --count <the number of workflow runs>  The number of workflow runs to analyze (default: 100)
    --workflow <workflow name>             The workflow name
    --workflow-actor <actor>               The workflow run actor
@@ -35,7 +43,37 @@ OPTIONS:
    --workflow-created <date range>        The workflow run created date range
    --workflow-status <status>             The workflow run status
    --config <path>                        The config file path
+   --actions                              Show the leaderboard of actions used in steps
+   --cost                                 Show the estimated cost of runners
+   --format <markdown|html|csv|tsv>       The report format (default: markdown)
    --init                                 Initialize the config file
+   --addr <address>                       The address of ghaperf serve (default: 127.0.0.1:8080)
+   --history                              Record samples of analyzed workflow runs for ghaperf history
+   --job <job name>                       The job name of ghaperf history
+   --step <step name>                     The step name of ghaperf history
+   --group <log group name>               The log group name of ghaperf history
+   --bucket <day|week>                    The period of ghaperf history (default: week)
+   --since <time duration>                Show samples newer than the duration in ghaperf history (e.g., 2160h)
+   --trend <day|week>                     Show trends of slow jobs and steps over days or weeks
+   --trend-stat <avg|p90>                 The statistic of --trend (default: avg)
+   --change-point                         Find workflow runs which made jobs and steps slower or faster. --workflow-branch is required
+   --attempts                             Analyze previous attempts of re-run workflow runs
+   --job-conclusion <conclusion>          Only analyze jobs with the conclusion (e.g. success). This option can be repeated
+   --run-conclusion <conclusion>          Only analyze workflow runs with the conclusion (e.g. success). This option can be repeated. Multiple conclusions are filtered after --count workflow runs are fetched
+   --by-conclusion                        Show durations of jobs by conclusion and the time to failure
+   --hangs                                Find jobs which timed out or were cancelled, and the steps where they stopped producing output
+   --comment                              Post the report as a pull request comment or update the comment posted by ghaperf
+   --pr <pull request number>             The pull request number of --comment. By default, the pull request of the workflow run is used
+   --job-summary                          Write the report to the job summary and set step outputs in GitHub Actions. By default, the current workflow run is analyzed
+   --budget <time duration>               The budget of the workflow run duration for the budget_status output of --job-summary (e.g., 10m)
+   --interval <time duration>             The polling interval of ghaperf watch (default: 10s)
+   --no-cache                             Neither read nor write the cache
+   --refresh                              Ignore the cache and overwrite it with data fetched from GitHub API
+   --offline                              Analyze only cached data without GitHub API. Workflow runs which aren't cached are reported
+   --older-than <time duration>           Remove cache which hasn't been updated for the duration by ghaperf cache prune (e.g., 720h)
+   --max-size <size>                      Remove the oldest cache until the cache size is less than or equal to the size by ghaperf cache prune (e.g., 1GB)
+   --dry-run                              Show cache which ghaperf cache prune would remove without removing it
+   --fix                                  Remove broken cache found by ghaperf cache verify
    --help, -h                             Show help
    --version, -v                          Show version
 
@@ -53,7 +91,7 @@ func parseFlags(f *controller.InputRun) {
 	pflag.IntVar(&f.AttemptNumber, "attempt-number", 0, "workflow run's attempt number")
 	pflag.Int64Var(&f.JobID, "job-id", 0, "job ID")
 	pflag.StringVar(&f.Threshold, "threshold", "", "threshold")
-	pflag.StringVar(&f.LogFile, "log-file", "", "log file")
+	pflag.StringArrayVar(&f.LogFiles, "log-file", nil, "log file")
 	pflag.BoolVarP(&f.Help, "help", "h", false, "Show help")
 	pflag.BoolVar(&f.Init, "init", false, "Initialize the config file")
 	pflag.BoolVarP(&f.Version, "version", "v", false, "Show version")
@@ -65,6 +103,36 @@ func parseFlags(f *controller.InputRun) {
 	pflag.StringVar(&f.ListWorkflowRunsOptions.Created, "workflow-created", "", "the workflow run created date range")
 	pflag.StringVar(&f.ListWorkflowRunsOptions.Status, "workflow-status", "", "the workflow run status")
 	pflag.StringVar(&f.Config, "config", "", "the config file path")
+	pflag.BoolVar(&f.ActionReport, "actions", false, "show the leaderboard of actions used in steps")
+	pflag.BoolVar(&f.CostReport, "cost", false, "show the estimated cost of runners")
+	pflag.StringVar(&f.Addr, "addr", "", "the address of ghaperf serve")
+	pflag.StringVar(&f.Format, "format", "", "the report format (markdown, html, csv, tsv)")
+	pflag.BoolVar(&f.History, "history", false, "record samples of analyzed workflow runs")
+	pflag.StringVar(&f.HistoryJob, "job", "", "the job name of ghaperf history")
+	pflag.StringVar(&f.HistoryStep, "step", "", "the step name of ghaperf history")
+	pflag.StringVar(&f.HistoryGroup, "group", "", "the log group name of ghaperf history")
+	pflag.StringVar(&f.Bucket, "bucket", "", "the period of ghaperf history (day, week)")
+	pflag.StringVar(&f.Since, "since", "", "show samples newer than the duration in ghaperf history")
+	pflag.StringVar(&f.Trend, "trend", "", "show trends of slow jobs and steps (day, week)")
+	pflag.StringVar(&f.TrendStat, "trend-stat", "", "the statistic of --trend (avg, p90)")
+	pflag.BoolVar(&f.ChangePoint, "change-point", false, "find workflow runs which made jobs and steps slower or faster")
+	pflag.BoolVar(&f.Attempts, "attempts", false, "analyze previous attempts of re-run workflow runs")
+	pflag.StringSliceVar(&f.JobConclusions, "job-conclusion", nil, "only analyze jobs with the conclusion")
+	pflag.StringSliceVar(&f.RunConclusions, "run-conclusion", nil, "only analyze workflow runs with the conclusion")
+	pflag.BoolVar(&f.ByConclusion, "by-conclusion", false, "show durations of jobs by conclusion")
+	pflag.BoolVar(&f.Hangs, "hangs", false, "find jobs which timed out or were cancelled")
+	pflag.BoolVar(&f.Comment, "comment", false, "post the report as a pull request comment")
+	pflag.IntVar(&f.PRNumber, "pr", 0, "the pull request number of --comment")
+	pflag.BoolVar(&f.JobSummary, "job-summary", false, "write the report to the job summary and set step outputs in GitHub Actions")
+	pflag.StringVar(&f.Budget, "budget", "", "the budget of the workflow run duration")
+	pflag.StringVar(&f.Interval, "interval", "", "the polling interval of ghaperf watch")
+	pflag.BoolVar(&f.NoCache, "no-cache", false, "neither read nor write the cache")
+	pflag.BoolVar(&f.Refresh, "refresh", false, "ignore the cache and overwrite it")
+	pflag.BoolVar(&f.Offline, "offline", false, "analyze only cached data without GitHub API")
+	pflag.StringVar(&f.OlderThan, "older-than", "", "remove cache which hasn't been updated for the duration")
+	pflag.StringVar(&f.MaxSize, "max-size", "", "the max size of the cache")
+	pflag.BoolVar(&f.DryRun, "dry-run", false, "show cache which would be removed without removing it")
+	pflag.BoolVar(&f.Fix, "fix", false, "remove broken cache")
 
 	pflag.Parse()
 	f.Args = pflag.Args()
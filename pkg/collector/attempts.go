@@ -0,0 +1,36 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// ListAttempts returns previous attempts of the workflow run.
+// Logs of previous attempts aren't downloaded because conclusions of jobs and steps are enough to analyze retries.
+func (r *Collector) ListAttempts(ctx context.Context, logger *slog.Logger, input *Input, run *github.WorkflowRun) ([]*WorkflowRun, error) {
+	arr := make([]*WorkflowRun, 0, max(run.GetRunAttempt()-1, 0))
+	for attempt := 1; attempt < run.GetRunAttempt(); attempt++ {
+		logArgs := []any{"run_id", run.GetID(), "run_attempt", attempt}
+		attemptRun, err := r.getRun(ctx, logger, input, run.GetID(), attempt)
+		if err != nil {
+			return nil, fmt.Errorf("get a workflow run attempt: %w", slogerr.With(err, logArgs...))
+		}
+		// list jobs of the attempt rather than the latest attempt
+		attemptInput := *input
+		attemptInput.AttemptNumber = attempt
+		jobs, err := r.getJobs(ctx, logger, &attemptInput, attemptRun)
+		if err != nil {
+			return nil, fmt.Errorf("get jobs of a workflow run attempt: %w", slogerr.With(err, logArgs...))
+		}
+		jobM := newJobMap(input, jobs)
+		arr = append(arr, &WorkflowRun{
+			Run:  attemptRun,
+			Jobs: jobList(jobM),
+		})
+	}
+	return arr, nil
+}
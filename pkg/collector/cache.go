@@ -0,0 +1,207 @@
+package collector
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/xdg"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// The following functions read data only from the cache and never call GitHub API.
+
+var ErrNotCached = errors.New("not cached")
+
+type Repo struct {
+	Owner string
+	Name  string
+}
+
+// ListCachedRepos returns repositories which have cached workflow runs.
+func (r *Collector) ListCachedRepos(cacheDir string) ([]*Repo, error) {
+	runsDir := filepath.Join(cacheDir, "runs")
+	owners, err := readDirNames(r.fs, runsDir)
+	if err != nil {
+		return nil, err
+	}
+	repos := []*Repo{}
+	for _, owner := range owners {
+		names, err := readDirNames(r.fs, filepath.Join(runsDir, owner))
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			repos = append(repos, &Repo{
+				Owner: owner,
+				Name:  name,
+			})
+		}
+	}
+	return repos, nil
+}
+
+// ListCachedRuns returns cached workflow runs of the repository.
+// Broken cache files are skipped.
+func (r *Collector) ListCachedRuns(logger *slog.Logger, input *Input) ([]*github.WorkflowRun, error) {
+	runsDir := xdg.RunsCacheDir(input.CacheDir, input.RepoOwner, input.RepoName)
+	runIDs, err := readDirNames(r.fs, runsDir)
+	if err != nil {
+		return nil, err
+	}
+	runs := []*github.WorkflowRun{}
+	for _, runID := range runIDs {
+		attempts, err := readDirNames(r.fs, filepath.Join(runsDir, runID))
+		if err != nil {
+			return nil, err
+		}
+		for _, attempt := range attempts {
+			run := &github.WorkflowRun{}
+			if err := r.readJSON(filepath.Join(runsDir, runID, attempt, "run.json"), run); err != nil {
+				if !errors.Is(err, ErrNotCached) {
+					slogerr.WithError(logger, err).Warn("read a cached workflow run", "run_id", runID, "run_attempt", attempt)
+				}
+				continue
+			}
+			runs = append(runs, run)
+		}
+	}
+	return runs, nil
+}
+
+// GetCachedRun returns a workflow run, its jobs, and their log groups from the cache.
+func (r *Collector) GetCachedRun(logger *slog.Logger, input *Input, runID int64, attempt int) (*WorkflowRun, error) {
+	run := &github.WorkflowRun{}
+	if err := r.readJSON(xdg.RunCache(input.CacheDir, input.RepoOwner, input.RepoName, runID, attempt), run); err != nil {
+		return nil, fmt.Errorf("read a cached workflow run: %w", err)
+	}
+	jobIDs := []int64{}
+	if err := r.readJSON(xdg.RunJobIDsCache(input.CacheDir, input.RepoOwner, input.RepoName, runID, attempt), &jobIDs); err != nil {
+		return nil, fmt.Errorf("read cached job ids: %w", err)
+	}
+	jobM := make(map[string]*Job, len(jobIDs))
+	jobs := make([]*Job, 0, len(jobIDs))
+	for _, jobID := range jobIDs {
+		job := &github.WorkflowJob{}
+		if err := r.readJSON(xdg.JobCache(input.CacheDir, input.RepoOwner, input.RepoName, jobID), job); err != nil {
+			return nil, fmt.Errorf("read a cached job: %w", slogerr.With(err, "job_id", jobID))
+		}
+		if !input.Config.Include(job.GetName()) {
+			continue
+		}
+		j := &Job{
+			Job:            job,
+			NormalizedName: input.Config.NormalizeJobName(job.GetName()),
+		}
+		jobM[job.GetName()] = j
+		jobs = append(jobs, j)
+	}
+	logCacheFile := xdg.RunLogCacheFile(input.CacheDir, input.RepoOwner, input.RepoName, runID, attempt)
+	if f, err := afero.Exists(r.fs, logCacheFile); err != nil || !f {
+		logger.Warn("logs of the workflow run aren't cached", "run_id", runID, "run_attempt", attempt)
+		return &WorkflowRun{
+			Run:  run,
+			Jobs: jobs,
+		}, nil
+	}
+	if err := r.readCachedLog(logger, xdg.RunLogCache(input.CacheDir, input.RepoOwner, input.RepoName, runID, attempt), jobM); err != nil {
+		return nil, fmt.Errorf("read cached logs: %w", err)
+	}
+	return &WorkflowRun{
+		Run:  run,
+		Jobs: jobs,
+	}, nil
+}
+
+// GetCachedJob returns a job and its log groups from the cache.
+// The job log is read from the cached job log or the cached logs of the workflow run.
+func (r *Collector) GetCachedJob(logger *slog.Logger, input *Input, jobID int64) (*Job, error) {
+	jobCachePath := xdg.JobCache(input.CacheDir, input.RepoOwner, input.RepoName, jobID)
+	job := &github.WorkflowJob{}
+	if err := r.readJSON(jobCachePath, job); err != nil {
+		return nil, fmt.Errorf("read a cached job: %w", err)
+	}
+	j := &Job{
+		Job:            job,
+		NormalizedName: input.Config.NormalizeJobName(job.GetName()),
+	}
+	jobLogPath, err := r.migrateLog(xdg.JobLogCache(jobCachePath))
+	if err != nil {
+		slogerr.WithError(logger, err).Warn("migrate a cached job log", "job_id", jobID)
+	}
+	if f, err := afero.Exists(r.fs, jobLogPath); err == nil && f {
+		log, err := r.readLog(jobLogPath)
+		if err != nil {
+			return nil, err
+		}
+		WarnLineErrors(logger, log, "job_id", jobID)
+		j.Groups = log.Groups
+		return j, nil
+	}
+	logCacheFile := xdg.RunLogCacheFile(input.CacheDir, input.RepoOwner, input.RepoName, job.GetRunID(), int(job.GetRunAttempt()))
+	if f, err := afero.Exists(r.fs, logCacheFile); err != nil || !f {
+		logger.Warn("the job log isn't cached", "job_id", jobID)
+		return j, nil
+	}
+	jobM := map[string]*Job{job.GetName(): j}
+	if err := r.readCachedLog(logger, xdg.RunLogCache(input.CacheDir, input.RepoOwner, input.RepoName, job.GetRunID(), int(job.GetRunAttempt())), jobM); err != nil {
+		return nil, fmt.Errorf("read cached logs: %w", err)
+	}
+	return j, nil
+}
+
+// readCachedJSON reads a cached JSON file and returns true if the file is cached.
+// Broken files (e.g. half-written files by old ghaperf) are removed and regarded as not cached, so they are fetched again.
+func (r *Collector) readCachedJSON(logger *slog.Logger, path string, dest any) (bool, error) {
+	b, err := afero.ReadFile(r.fs, path)
+	if err != nil {
+		if errors.Is(err, afero.ErrFileNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("read a cache file: %w", slogerr.With(err, "path", path))
+	}
+	if err := json.Unmarshal(b, dest); err != nil {
+		slogerr.WithError(logger, err).Warn("remove a broken cache file", "path", path)
+		if err := r.fs.Remove(path); err != nil {
+			return false, fmt.Errorf("remove a broken cache file: %w", slogerr.With(err, "path", path))
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+func (r *Collector) readJSON(path string, dest any) error {
+	b, err := afero.ReadFile(r.fs, path)
+	if err != nil {
+		if errors.Is(err, afero.ErrFileNotFound) {
+			return slogerr.With(ErrNotCached, "path", path) //nolint:wrapcheck
+		}
+		return fmt.Errorf("read a cache file: %w", slogerr.With(err, "path", path))
+	}
+	if err := json.Unmarshal(b, dest); err != nil {
+		return fmt.Errorf("unmarshal a cache file: %w", slogerr.With(err, "path", path))
+	}
+	return nil
+}
+
+func readDirNames(fs afero.Fs, dir string) ([]string, error) {
+	infos, err := afero.ReadDir(fs, dir)
+	if err != nil {
+		if errors.Is(err, afero.ErrFileNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read a cache directory: %w", slogerr.With(err, "dir", dir))
+	}
+	names := make([]string, 0, len(infos))
+	for _, info := range infos {
+		if !info.IsDir() {
+			continue
+		}
+		names = append(names, info.Name())
+	}
+	return names, nil
+}
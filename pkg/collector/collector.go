@@ -1,7 +1,6 @@
 package collector
 
 import (
-	"archive/zip"
 	"context"
 	"io"
 	"time"
@@ -23,7 +22,7 @@ type GitHub interface {
 	GetWorkflowRunByID(ctx context.Context, owner, repo string, runID int64, attempt int) (*github.WorkflowRun, error)
 	ListWorkflowJobs(ctx context.Context, owner, repo string, runID int64, attempt int) ([]*github.WorkflowJob, error)
 	ListWorkflowRuns(ctx context.Context, owner, repo string, fileName string, maxCount int, opts *github.ListWorkflowRunsOptions) ([]*github.WorkflowRun, error)
-	GetWorkflowRunLogs(ctx context.Context, owner, repo string, runID int64, attempt int) ([]*zip.File, error)
+	GetWorkflowRunLogs(ctx context.Context, owner, repo string, runID int64, attempt int) (*github.RunLogs, error)
 }
 
 func New(fs afero.Fs, gh GitHub) *Collector {
@@ -35,8 +34,11 @@ func New(fs afero.Fs, gh GitHub) *Collector {
 
 type Input struct {
 	Threshold               time.Duration
-	LogFile                 string
+	LogFiles                []string
 	CacheDir                string
+	Refresh                 bool
+	Offline                 bool
+	HistoryDir              string
 	RepoOwner               string
 	RepoName                string
 	RunID                   int64
@@ -47,6 +49,19 @@ type Input struct {
 	ListWorkflowRunsOptions *github.ListWorkflowRunsOptions
 	Config                  *config.Config
 	Version                 string
+	ActionReport            bool
+	CostReport              bool
+	Trend                   string
+	TrendStat               string
+	ChangePoint             bool
+	Attempts                bool
+	ByConclusion            bool
+	Hangs                   bool
+	Comment                 bool
+	PRNumber                int
+	OutputFile              string
+	StepSummaryFile         string
+	Budget                  time.Duration
 }
 
 type Job struct {
@@ -55,6 +70,8 @@ type Job struct {
 	duration       time.Duration
 	NormalizedName string
 	LogHasGone     bool
+	// Partial is true if the job isn't completed yet, so steps and log groups are partial
+	Partial bool
 }
 
 func (j *Job) Duration() time.Duration {
@@ -75,3 +92,14 @@ func (j *Job) Duration() time.Duration {
 	j.duration = completedAt.Sub(startedAt)
 	return j.duration
 }
+
+// StepDuration returns the duration of the step.
+// It returns false if the step isn't completed, because queued, in-progress, and some skipped steps don't have started_at or completed_at.
+func StepDuration(step *github.TaskStep) (time.Duration, bool) {
+	startedAt := step.GetStartedAt().Time
+	completedAt := step.GetCompletedAt().Time
+	if startedAt.IsZero() || completedAt.IsZero() || completedAt.Before(startedAt) {
+		return 0, false
+	}
+	return completedAt.Sub(startedAt), true
+}
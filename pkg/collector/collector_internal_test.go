@@ -0,0 +1,37 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+)
+
+func TestStepDuration(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	ts := func(d time.Duration) *github.Timestamp {
+		return &github.Timestamp{Time: start.Add(d)}
+	}
+	tests := []struct {
+		name string
+		step *github.TaskStep
+		want time.Duration
+		ok   bool
+	}{
+		{name: "completed", step: &github.TaskStep{StartedAt: ts(0), CompletedAt: ts(time.Minute)}, want: time.Minute, ok: true},
+		{name: "in progress", step: &github.TaskStep{StartedAt: ts(0)}},
+		{name: "queued", step: &github.TaskStep{}},
+		{name: "no started_at", step: &github.TaskStep{CompletedAt: ts(time.Minute)}},
+		{name: "completed before started", step: &github.TaskStep{StartedAt: ts(time.Minute), CompletedAt: ts(0)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			d, ok := StepDuration(tt.step)
+			if d != tt.want || ok != tt.ok {
+				t.Errorf("StepDuration() = (%s, %v), want (%s, %v)", d, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package collector
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/cache"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/xdg"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// runIndex is a cached list of workflow runs of a workflow.
+// Listing workflow runs requires the GitHub API, so offline mode lists workflow runs from the index.
+// The index is keyed by the workflow and filters because they change the list.
+type runIndex struct {
+	Workflow string     `json:"workflow"`
+	Filter   *runFilter `json:"filter"`
+	Runs     []*runRef  `json:"runs"`
+}
+
+type runFilter struct {
+	Actor               string `json:"actor,omitempty"`
+	Branch              string `json:"branch,omitempty"`
+	Event               string `json:"event,omitempty"`
+	Status              string `json:"status,omitempty"`
+	Created             string `json:"created,omitempty"`
+	HeadSHA             string `json:"head_sha,omitempty"`
+	ExcludePullRequests bool   `json:"exclude_pull_requests,omitempty"`
+	CheckSuiteID        int64  `json:"check_suite_id,omitempty"`
+}
+
+// runRef refers to a cached workflow run.
+// The conclusion is kept so that workflow runs excluded by the configuration aren't reported as missing.
+type runRef struct {
+	ID         int64  `json:"id"`
+	Attempt    int    `json:"attempt"`
+	Conclusion string `json:"conclusion,omitempty"`
+}
+
+func newRunFilter(opts *github.ListWorkflowRunsOptions) *runFilter {
+	if opts == nil {
+		return &runFilter{}
+	}
+	return &runFilter{
+		Actor:               opts.Actor,
+		Branch:              opts.Branch,
+		Event:               opts.Event,
+		Status:              opts.Status,
+		Created:             opts.Created,
+		HeadSHA:             opts.HeadSHA,
+		ExcludePullRequests: opts.ExcludePullRequests,
+		CheckSuiteID:        opts.CheckSuiteID,
+	}
+}
+
+func runIndexPath(input *Input) (string, error) {
+	b, err := json.Marshal(newRunFilter(input.ListWorkflowRunsOptions))
+	if err != nil {
+		return "", fmt.Errorf("marshal filters of workflow runs: %w", err)
+	}
+	sum := sha256.Sum256(b)
+	return xdg.RunIndexCache(input.CacheDir, input.RepoOwner, input.RepoName, input.WorkflowName, hex.EncodeToString(sum[:8])), nil
+}
+
+// cacheRunIndex adds workflow runs to the index.
+// Workflow runs in the old index are kept so that listing fewer workflow runs doesn't shrink the index.
+func (r *Collector) cacheRunIndex(logger *slog.Logger, input *Input, runs []*github.WorkflowRun) error {
+	path, err := runIndexPath(input)
+	if err != nil {
+		return err
+	}
+	index := &runIndex{}
+	if _, err := r.readCachedJSON(logger, path, index); err != nil {
+		return fmt.Errorf("read the cached index of workflow runs: %w", err)
+	}
+	refs := make(map[int64]*runRef, len(index.Runs)+len(runs))
+	for _, ref := range index.Runs {
+		refs[ref.ID] = ref
+	}
+	for _, run := range runs {
+		refs[run.GetID()] = &runRef{
+			ID:         run.GetID(),
+			Attempt:    run.GetRunAttempt(),
+			Conclusion: run.GetConclusion(),
+		}
+	}
+	index = &runIndex{
+		Workflow: input.WorkflowName,
+		Filter:   newRunFilter(input.ListWorkflowRunsOptions),
+		Runs:     make([]*runRef, 0, len(refs)),
+	}
+	for _, ref := range refs {
+		index.Runs = append(index.Runs, ref)
+	}
+	// the newest workflow run first like the GitHub API
+	slices.SortFunc(index.Runs, func(a, b *runRef) int {
+		return cmp.Compare(b.ID, a.ID)
+	})
+	b, err := json.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("marshal the index of workflow runs: %w", err)
+	}
+	if err := r.fs.MkdirAll(filepath.Dir(path), dirPermission); err != nil {
+		return fmt.Errorf("make dirs for the cached index of workflow runs: %w", err)
+	}
+	if err := cache.WriteFile(r.fs, path, b); err != nil {
+		return fmt.Errorf("write the cached index of workflow runs: %w", err)
+	}
+	return nil
+}
+
+var errRunIndexNotCached = fmt.Errorf("workflow runs aren't listed online with the same workflow and filters: %w", ErrNotCached)
+
+// listIndexedRuns lists workflow runs from the index in offline mode.
+// Workflow runs which aren't cached are returned as missing runs.
+func (r *Collector) listIndexedRuns(logger *slog.Logger, input *Input, maxCount int) ([]*github.WorkflowRun, []*runRef, error) {
+	path, err := runIndexPath(input)
+	if err != nil {
+		return nil, nil, err
+	}
+	index := &runIndex{}
+	found, err := r.readCachedJSON(logger, path, index)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read the cached index of workflow runs: %w", err)
+	}
+	if !found {
+		return nil, nil, slogerr.With(errRunIndexNotCached, "workflow", input.WorkflowName) //nolint:wrapcheck
+	}
+	runs := []*github.WorkflowRun{}
+	missing := []*runRef{}
+	// workflow runs excluded by the conclusion are counted like the GitHub API
+	for _, ref := range index.Runs[:min(maxCount, len(index.Runs))] {
+		run := &github.WorkflowRun{}
+		found, err := r.readCachedJSON(logger, xdg.RunCache(input.CacheDir, input.RepoOwner, input.RepoName, ref.ID, ref.Attempt), run)
+		if err != nil {
+			return nil, nil, fmt.Errorf("read a cached workflow run: %w", slogerr.With(err, "run_id", ref.ID, "run_attempt", ref.Attempt))
+		}
+		if !found {
+			if ref.Conclusion == "" || input.Config.IncludeRunConclusion(ref.Conclusion) {
+				missing = append(missing, ref)
+			}
+			continue
+		}
+		runs = append(runs, run)
+	}
+	return runs, missing, nil
+}
+
+// latestCachedAttempt returns the latest cached attempt of the workflow run.
+// It returns 0 if no attempt is cached.
+func (r *Collector) latestCachedAttempt(input *Input, runID int64) int {
+	names, err := readDirNames(r.fs, filepath.Join(xdg.RunsCacheDir(input.CacheDir, input.RepoOwner, input.RepoName), strconv.FormatInt(runID, 10)))
+	if err != nil {
+		return 0
+	}
+	latest := 0
+	for _, name := range names {
+		attempt, err := strconv.Atoi(name)
+		if err != nil {
+			continue
+		}
+		latest = max(latest, attempt)
+	}
+	return latest
+}
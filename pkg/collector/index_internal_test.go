@@ -0,0 +1,68 @@
+package collector
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/config"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/xdg"
+)
+
+func newRun(id int64, attempt int, conclusion string) *github.WorkflowRun {
+	status := statusCompleted
+	return &github.WorkflowRun{
+		ID:         &id,
+		RunAttempt: &attempt,
+		Status:     &status,
+		Conclusion: &conclusion,
+	}
+}
+
+func TestCollector_listIndexedRuns(t *testing.T) {
+	t.Parallel()
+	logger := slog.New(slog.DiscardHandler)
+	fs := afero.NewMemMapFs()
+	c := New(fs, &github.Offline{})
+	input := &Input{
+		CacheDir:                "/cache",
+		RepoOwner:               "suzuki-shunsuke",
+		RepoName:                "ghaperf",
+		WorkflowName:            "test.yaml",
+		ListWorkflowRunsOptions: &github.ListWorkflowRunsOptions{Branch: "main"},
+		Config:                  &config.Config{RunConclusions: []string{"success"}},
+	}
+	// the second list doesn't shrink the index
+	if err := c.cacheRunIndex(logger, input, []*github.WorkflowRun{newRun(4, 1, "success"), newRun(3, 2, "success"), newRun(2, 1, "failure"), newRun(1, 1, "success")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.cacheRunIndex(logger, input, []*github.WorkflowRun{newRun(5, 1, "success")}); err != nil {
+		t.Fatal(err)
+	}
+	for _, run := range []*github.WorkflowRun{newRun(5, 1, "success"), newRun(3, 2, "success")} {
+		if err := c.cacheRun(run, xdg.RunCache(input.CacheDir, input.RepoOwner, input.RepoName, run.GetID(), run.GetRunAttempt())); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	runs, missing, err := c.listIndexedRuns(logger, input, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 2 || runs[0].GetID() != 5 || runs[1].GetID() != 3 {
+		t.Errorf("cached workflow runs = %v, want 5 and 3", runs)
+	}
+	// the run 2 isn't missing because it's excluded by the conclusion
+	if len(missing) != 1 || missing[0].ID != 4 {
+		t.Errorf("missing workflow runs = %v, want 4", missing)
+	}
+
+	input.ListWorkflowRunsOptions = &github.ListWorkflowRunsOptions{Branch: "feature"}
+	if _, _, err := c.listIndexedRuns(logger, input, 4); err == nil {
+		t.Error("the index of other filters should not be used")
+	}
+	if attempt := c.latestCachedAttempt(input, 3); attempt != 2 {
+		t.Errorf("the latest cached attempt = %d, want 2", attempt)
+	}
+}
@@ -1,7 +1,6 @@
 package collector
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -9,14 +8,17 @@ import (
 	"log/slog"
 	"path/filepath"
 
-	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/cache"
 	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
-	"github.com/suzuki-shunsuke/ghaperf/pkg/parser"
 	"github.com/suzuki-shunsuke/ghaperf/pkg/xdg"
 	"github.com/suzuki-shunsuke/slog-error/slogerr"
 )
 
 func (c *Collector) GetJob(ctx context.Context, logger *slog.Logger, input *Input, jobID int64) (*Job, error) {
+	if input.Offline {
+		// the job log may be cached as a part of the logs of the workflow run
+		return c.GetCachedJob(logger, input, jobID)
+	}
 	job, err := c.getJob(ctx, logger, input, jobID)
 	if err != nil {
 		return nil, fmt.Errorf("get a job: %w", err)
@@ -25,7 +27,8 @@ func (c *Collector) GetJob(ctx context.Context, logger *slog.Logger, input *Inpu
 	if job.GetStatus() != statusCompleted {
 		logger.Warn("job is not completed yet", logArgs...)
 		return &Job{
-			Job: job,
+			Job:     job,
+			Partial: true,
 		}, nil
 	}
 	if job.GetConclusion() == "skipped" {
@@ -33,7 +36,7 @@ func (c *Collector) GetJob(ctx context.Context, logger *slog.Logger, input *Inpu
 			Job: job,
 		}, nil
 	}
-	jobLog, err := c.GetJobLog(ctx, input, jobID)
+	log, err := c.GetJobLog(ctx, input, jobID)
 	if err != nil {
 		slogerr.WithError(logger, err).Error("get a job log", logArgs...)
 		return &Job{
@@ -41,14 +44,7 @@ func (c *Collector) GetJob(ctx context.Context, logger *slog.Logger, input *Inpu
 			LogHasGone: errors.Is(err, github.ErrLogHasGone),
 		}, nil
 	}
-	log, err := parser.Parse(bytes.NewBuffer(jobLog))
-	if err != nil {
-		slogerr.WithError(logger, err).Error("parse a job log", logArgs...)
-		return &Job{
-			Job:    job,
-			Groups: log.Groups,
-		}, nil
-	}
+	WarnLineErrors(logger, log, logArgs...)
 	return &Job{
 		Job:    job,
 		Groups: log.Groups,
@@ -57,19 +53,18 @@ func (c *Collector) GetJob(ctx context.Context, logger *slog.Logger, input *Inpu
 
 func (c *Collector) getJob(ctx context.Context, logger *slog.Logger, input *Input, jobID int64) (*github.WorkflowJob, error) {
 	jobCachePath := xdg.JobCache(input.CacheDir, input.RepoOwner, input.RepoName, jobID)
+	if input.Refresh {
+		return c.getAndCacheJob(ctx, logger, input, jobID, jobCachePath)
+	}
 	job := &github.WorkflowJob{}
-	b, err := afero.ReadFile(c.fs, jobCachePath)
+	found, err := c.readCachedJSON(logger, jobCachePath, job)
 	if err != nil {
-		if !errors.Is(err, afero.ErrFileNotFound) {
-			return nil, fmt.Errorf("read cached job file: %w", err)
-		}
-		// cache not found
+		return nil, fmt.Errorf("read cached job file: %w", err)
+	}
+	if !found {
 		return c.getAndCacheJob(ctx, logger, input, jobID, jobCachePath)
 	}
 	// exist cache
-	if err := json.Unmarshal(b, job); err != nil {
-		return nil, fmt.Errorf("unmarshal cached job file: %w", err)
-	}
 	return job, nil
 }
 
@@ -99,7 +94,7 @@ func (c *Collector) cacheJob(jobCachePath string, job *github.WorkflowJob) error
 	if err != nil {
 		return fmt.Errorf("marshal job file: %w", err)
 	}
-	if err := afero.WriteFile(c.fs, jobCachePath, b, filePermission); err != nil {
+	if err := cache.WriteFile(c.fs, jobCachePath, b); err != nil {
 		return fmt.Errorf("write cached job file: %w", err)
 	}
 	return nil
@@ -4,41 +4,140 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"io"
+	"log/slog"
+	"path/filepath"
+	"strings"
 
 	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/cache"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/parser"
 	"github.com/suzuki-shunsuke/ghaperf/pkg/xdg"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
 )
 
-func (c *Collector) GetJobLog(ctx context.Context, input *Input, jobID int64) ([]byte, error) {
+// GetJobLog gets a job log and parses it.
+// The log is streamed into the cache and parsed from the cached file, so the whole log isn't loaded on memory.
+func (c *Collector) GetJobLog(ctx context.Context, input *Input, jobID int64) (*parser.Log, error) {
 	cachePath := xdg.JobLogCache(xdg.JobCache(input.CacheDir, input.RepoOwner, input.RepoName, jobID))
-	b, err := afero.ReadFile(c.fs, cachePath)
+	if input.Refresh {
+		return c.getAndCacheLog(ctx, input, jobID, cachePath)
+	}
+	log, found, err := c.readCachedJobLog(cachePath)
 	if err != nil {
-		if !errors.Is(err, afero.ErrFileNotFound) {
-			return nil, fmt.Errorf("read cached job log file: %w", err)
+		return nil, err
+	}
+	if found {
+		return log, nil
+	}
+	return c.getAndCacheLog(ctx, input, jobID, cachePath)
+}
+
+// readCachedJobLog parses a cached job log and returns true if the log is cached.
+// Broken logs are removed and regarded as not cached, so they are downloaded again.
+func (c *Collector) readCachedJobLog(cachePath string) (*parser.Log, bool, error) {
+	// If the migration fails, the uncompressed log is read and the migration is retried next time
+	path, _ := c.migrateLog(cachePath)
+	r, err := cache.Open(c.fs, path)
+	if err != nil {
+		if errors.Is(err, afero.ErrFileNotFound) {
+			return nil, false, nil
 		}
-		// cache not found
-		return c.getAndCacheLog(ctx, input, jobID, cachePath)
+		if cache.IsCorrupt(err) {
+			return nil, false, c.removeBrokenJobLog(path)
+		}
+		return nil, false, fmt.Errorf("open cached job log file: %w", err)
+	}
+	defer r.Close()
+	log, err := parser.Parse(r)
+	if err != nil {
+		if cache.IsCorrupt(err) {
+			return nil, false, c.removeBrokenJobLog(path)
+		}
+		return nil, false, fmt.Errorf("parse cached job log file: %w", err)
+	}
+	return log, true, nil
+}
+
+// WarnLineErrors outputs a warning if some lines of the log are skipped or parsed partially.
+// Only the first error is output with the number of errors so that a broken log doesn't flood the output.
+func WarnLineErrors(logger *slog.Logger, log *parser.Log, logArgs ...any) {
+	if len(log.Errors) == 0 {
+		return
+	}
+	slogerr.WithError(logger, log.Errors[0]).Warn("some lines of a log can't be parsed", append(logArgs, "line_errors", log.ErrorCount)...)
+}
+
+func (c *Collector) removeBrokenJobLog(path string) error {
+	if err := c.fs.Remove(path); err != nil {
+		return fmt.Errorf("remove a broken job log file: %w", slogerr.With(err, "path", path))
 	}
-	// exist cache
-	return b, nil
+	return nil
 }
 
-const filePermission = 0o644
+// migrateLog compresses the log file cached by old ghaperf without compression.
+// It returns the path to the log file which should be read.
+func (c *Collector) migrateLog(cachePath string) (string, error) {
+	if f, err := afero.Exists(c.fs, cachePath); err != nil || f {
+		return cachePath, nil //nolint:nilerr
+	}
+	legacyPath := strings.TrimSuffix(cachePath, cache.CompressedExt)
+	if f, err := afero.Exists(c.fs, legacyPath); err != nil || !f {
+		return cachePath, nil //nolint:nilerr
+	}
+	dest, err := cache.Compress(c.fs, legacyPath)
+	if err != nil {
+		return legacyPath, fmt.Errorf("compress a cached log file: %w", err)
+	}
+	return dest, nil
+}
+
+func (c *Collector) getAndCacheLog(ctx context.Context, input *Input, jobID int64, cachePath string) (*parser.Log, error) {
+	unlock, err := cache.Lock(ctx, c.fs, cachePath)
+	if err != nil {
+		return nil, fmt.Errorf("lock cached job log file: %w", err)
+	}
+	defer unlock()
+	if !input.Refresh {
+		// another process may cache the log while this process was waiting for the lock
+		if log, found, err := c.readCachedJobLog(cachePath); err == nil && found {
+			return log, nil
+		}
+	}
+	if err := c.cacheJobLog(ctx, input, jobID, cachePath); err != nil {
+		return nil, err
+	}
+	log, found, err := c.readCachedJobLog(cachePath)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, slogerr.With(errJobLogNotCached, "path", cachePath) //nolint:wrapcheck
+	}
+	return log, nil
+}
+
+var errJobLogNotCached = errors.New("the downloaded job log isn't cached")
 
-func (c *Collector) getAndCacheLog(ctx context.Context, input *Input, jobID int64, cachePath string) ([]byte, error) {
+// cacheJobLog streams a job log into the cache.
+func (c *Collector) cacheJobLog(ctx context.Context, input *Input, jobID int64, cachePath string) error {
 	logReader, err := c.gh.GetWorkflowJobLogs(ctx, input.RepoOwner, input.RepoName, jobID)
 	if err != nil {
-		return nil, fmt.Errorf("get workflow job logs: %w", err)
+		return fmt.Errorf("get workflow job logs: %w", err)
 	}
 	defer logReader.Close()
-	b, err := io.ReadAll(logReader)
+	if err := c.fs.MkdirAll(filepath.Dir(cachePath), dirPermission); err != nil {
+		return fmt.Errorf("create job cache dir: %w", err)
+	}
+	w, err := cache.Create(c.fs, cachePath)
 	if err != nil {
-		return nil, fmt.Errorf("read workflow job logs: %w", err)
+		return fmt.Errorf("create cached job log file: %w", err)
+	}
+	defer w.Close()
+	if err := copySafe(w, logReader); err != nil {
+		return fmt.Errorf("write cached job log file: %w", err)
 	}
-	// cache the job info
-	if err := afero.WriteFile(c.fs, cachePath, b, filePermission); err != nil {
-		return nil, fmt.Errorf("write cached job log file: %w", err)
+	if err := w.Commit(); err != nil {
+		return fmt.Errorf("write cached job log file: %w", err)
 	}
-	return b, nil
+	return nil
 }
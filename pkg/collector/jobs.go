@@ -2,8 +2,6 @@ package collector
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
 	"fmt"
 	"log/slog"
 
@@ -15,18 +13,18 @@ import (
 
 func (r *Collector) getJobs(ctx context.Context, logger *slog.Logger, input *Input, run *github.WorkflowRun) ([]*github.WorkflowJob, error) {
 	cachePath := xdg.RunJobIDsCache(input.CacheDir, input.RepoOwner, input.RepoName, run.GetID(), run.GetRunAttempt())
-	b, err := afero.ReadFile(r.fs, cachePath)
-	if err != nil {
-		if !errors.Is(err, afero.ErrFileNotFound) {
-			return nil, fmt.Errorf("read cached job IDs file: %w", err)
-		}
+	if input.Refresh {
 		return r.getAndCacheJobs(ctx, logger, input, cachePath, run)
 	}
 	jobIDs := []int64{}
-	// exist cache
-	if err := json.Unmarshal(b, &jobIDs); err != nil {
-		return nil, fmt.Errorf("unmarshal cached job ids: %w", err)
+	found, err := r.readCachedJSON(logger, cachePath, &jobIDs)
+	if err != nil {
+		return nil, fmt.Errorf("read cached job IDs file: %w", err)
 	}
+	if !found {
+		return r.getAndCacheJobs(ctx, logger, input, cachePath, run)
+	}
+	// exist cache
 	arr := make([]*github.WorkflowJob, len(jobIDs))
 	for i, jobID := range jobIDs {
 		job, err := r.getJob(ctx, logger, input, jobID)
@@ -65,7 +63,7 @@ func (r *Collector) cacheJobs(logger *slog.Logger, input *Input, jobs []*github.
 			continue
 		}
 		jobCachePath := xdg.JobCache(input.CacheDir, input.RepoOwner, input.RepoName, job.GetID())
-		if f, err := afero.Exists(r.fs, jobCachePath); err == nil && f {
+		if f, err := afero.Exists(r.fs, jobCachePath); err == nil && f && !input.Refresh {
 			continue
 		}
 		// cache the job info
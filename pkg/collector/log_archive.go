@@ -0,0 +1,256 @@
+package collector
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/parser"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// IsLogArchive returns true if the path is a log archive of a workflow run downloaded from GitHub or a directory of job log files.
+func IsLogArchive(fs afero.Fs, path string) (bool, error) {
+	if strings.EqualFold(filepath.Ext(path), ".zip") {
+		return true, nil
+	}
+	f, err := afero.IsDir(fs, path)
+	if err != nil {
+		return false, fmt.Errorf("check if the log file is a directory: %w", slogerr.With(err, "log_file", path))
+	}
+	return f, nil
+}
+
+// ReadLogArchive reads a log archive of a workflow run or a directory of job log files without GitHub API.
+// Jobs and their steps are built from logs because the GitHub API isn't available.
+// Like the log archive, only files at the top level are read as job logs and files in sub directories are ignored.
+func (r *Collector) ReadLogArchive(logger *slog.Logger, input *Input, path string) (*WorkflowRun, error) {
+	isDir, err := afero.IsDir(r.fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("check if the log file is a directory: %w", slogerr.With(err, "log_file", path))
+	}
+	var logs []*namedLog
+	if isDir {
+		logs, err = r.readLogDir(logger, path)
+	} else {
+		logs, err = r.readLogZip(logger, path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newRunFromLogs(input, strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)), logs), nil
+}
+
+// NewRunFromLogs builds a workflow run from job logs such as the output of `gh run view --log`.
+// Job names are read from logs.
+func NewRunFromLogs(input *Input, name string, logs []*parser.Log) *WorkflowRun {
+	namedLogs := make([]*namedLog, len(logs))
+	for i, log := range logs {
+		namedLogs[i] = &namedLog{log: log}
+	}
+	return newRunFromLogs(input, name, namedLogs)
+}
+
+func newRunFromLogs(input *Input, name string, logs []*namedLog) *WorkflowRun {
+	status := statusCompleted
+	run := &WorkflowRun{
+		Run: &github.WorkflowRun{
+			Name:   &name,
+			Status: &status,
+		},
+		Jobs: make([]*Job, 0, len(logs)),
+	}
+	for _, l := range logs {
+		if job := newJobFromLog(input, l.name, l.log); job != nil {
+			run.Jobs = append(run.Jobs, job)
+		}
+	}
+	return run
+}
+
+// ReadLogFiles reads job log files and builds jobs from them.
+// The output of `gh run view --log` has logs of multiple jobs, so a job is built for each log.
+// Files which can't be parsed are skipped.
+func (r *Collector) ReadLogFiles(logger *slog.Logger, input *Input, paths []string) []*Job {
+	jobs := make([]*Job, 0, len(paths))
+	for _, path := range paths {
+		logs, err := r.parseJobLogFile(path)
+		if err != nil {
+			slogerr.WithError(logger, err).Error("parse a log file", "log_file", path)
+			continue
+		}
+		for _, log := range logs {
+			WarnLineErrors(logger, log, "log_file", path, "job_name", log.JobName)
+			if job := newJobFromLog(input, filepath.Base(path), log); job != nil {
+				jobs = append(jobs, job)
+			}
+		}
+	}
+	return jobs
+}
+
+// ExpandLogFiles expands glob patterns of log files.
+// Patterns which match no file are regarded as file paths, so they fail when they are opened.
+func ExpandLogFiles(fs afero.Fs, patterns []string) ([]string, error) {
+	paths := []string{}
+	for _, pattern := range patterns {
+		matches, err := afero.Glob(fs, pattern)
+		if err != nil {
+			return nil, fmt.Errorf("expand a glob pattern of log files: %w", slogerr.With(err, "log_file", pattern))
+		}
+		if len(matches) == 0 {
+			paths = append(paths, pattern)
+			continue
+		}
+		paths = append(paths, matches...)
+	}
+	return paths, nil
+}
+
+type namedLog struct {
+	name string
+	log  *parser.Log
+}
+
+func (r *Collector) readLogZip(logger *slog.Logger, path string) ([]*namedLog, error) {
+	f, err := r.fs.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open a log archive: %w", slogerr.With(err, "log_file", path))
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("get a log archive info: %w", slogerr.With(err, "log_file", path))
+	}
+	zr, err := zip.NewReader(f, info.Size())
+	if err != nil {
+		return nil, fmt.Errorf("open a log archive as zip: %w", slogerr.With(err, "log_file", path))
+	}
+	logs := make([]*namedLog, 0, len(zr.File))
+	for _, file := range zr.File {
+		if strings.HasSuffix(file.Name, "/") || filepath.Dir(file.Name) != "." {
+			continue // skip directories and logs of steps in sub directories
+		}
+		log, err := parseZipFile(file)
+		if err != nil {
+			slogerr.WithError(logger, err).Error("parse a log file in a log archive", "file_name", file.Name)
+			continue
+		}
+		WarnLineErrors(logger, log, "file_name", file.Name)
+		logs = append(logs, &namedLog{name: file.Name, log: log})
+	}
+	return logs, nil
+}
+
+func parseZipFile(file *zip.File) (*parser.Log, error) {
+	f, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("open a log file in a log archive: %w", err)
+	}
+	defer f.Close()
+	return parseLogReader(f)
+}
+
+func (r *Collector) readLogDir(logger *slog.Logger, dir string) ([]*namedLog, error) {
+	infos, err := afero.ReadDir(r.fs, dir)
+	if err != nil {
+		return nil, fmt.Errorf("read a log directory: %w", slogerr.With(err, "log_file", dir))
+	}
+	logs := make([]*namedLog, 0, len(infos))
+	for _, info := range infos {
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".") {
+			continue
+		}
+		log, err := r.parseLogFile(filepath.Join(dir, info.Name()))
+		if err != nil {
+			slogerr.WithError(logger, err).Error("parse a log file in a log directory", "file_name", info.Name())
+			continue
+		}
+		WarnLineErrors(logger, log, "file_name", info.Name())
+		logs = append(logs, &namedLog{name: info.Name(), log: log})
+	}
+	return logs, nil
+}
+
+func (r *Collector) parseLogFile(path string) (*parser.Log, error) {
+	f, err := r.fs.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open a log file: %w", err)
+	}
+	defer f.Close()
+	return parseLogReader(f)
+}
+
+func (r *Collector) parseJobLogFile(path string) ([]*parser.Log, error) {
+	f, err := r.fs.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open a log file: %w", err)
+	}
+	defer f.Close()
+	logs, err := parser.ParseJobs(f)
+	if err != nil {
+		return nil, fmt.Errorf("parse a log file: %w", err)
+	}
+	return logs, nil
+}
+
+func parseLogReader(f io.Reader) (*parser.Log, error) {
+	log, err := parser.Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("parse a log file: %w", err)
+	}
+	return log, nil
+}
+
+// newJobFromLog builds a job from a job log.
+// The job name is read from the log, and if it isn't found the file name is used.
+// Files in log archives are named "<index>_<job name>.txt".
+// It returns nil if the job is excluded by the configuration.
+func newJobFromLog(input *Input, fileName string, log *parser.Log) *Job {
+	name := log.JobName
+	if name == "" {
+		name = strings.TrimSuffix(fileName, filepath.Ext(fileName))
+		if index, n, ok := strings.Cut(name, "_"); ok && isIndex(index) {
+			name = n
+		}
+	}
+	if !input.Config.Include(name) {
+		return nil
+	}
+	status := statusCompleted
+	job := &github.WorkflowJob{
+		Name:   &name,
+		Status: &status,
+		Steps:  make([]*github.TaskStep, len(log.Steps)),
+	}
+	for i, s := range log.Steps {
+		number := int64(i + 1)
+		job.Steps[i] = &github.TaskStep{
+			Name:        &s.Name,
+			Number:      &number,
+			Status:      &status,
+			StartedAt:   &github.Timestamp{Time: s.StartTime},
+			CompletedAt: &github.Timestamp{Time: s.EndTime},
+		}
+	}
+	if len(log.Steps) != 0 {
+		job.StartedAt = job.Steps[0].StartedAt
+		job.CompletedAt = job.Steps[len(job.Steps)-1].CompletedAt
+	}
+	return &Job{
+		Job:            job,
+		Groups:         log.Groups,
+		NormalizedName: input.Config.NormalizeJobName(name),
+	}
+}
+
+func isIndex(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
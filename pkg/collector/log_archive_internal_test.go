@@ -0,0 +1,40 @@
+package collector
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/config"
+)
+
+func TestCollector_ReadLogFiles(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	files := map[string]string{
+		// the output of `gh run view --log`
+		"/logs/run.log": "build\tSet up job\t2025-10-25T13:48:59.0000000Z Current runner version: '2.329.0'\n" +
+			"build\tRun make build\t2025-10-25T13:49:01.0000000Z ##[group]Run make build\n" +
+			"build\tRun make build\t2025-10-25T13:49:01.5000000Z ##[endgroup]\n" +
+			"test\tSet up job\t2025-10-25T13:50:00.0000000Z Current runner version: '2.329.0'\n" +
+			"test\tCache\t2025-10-25T13:50:02.0000000Z Cache restored\n",
+		"/logs/1_lint.txt": "2025-10-25T13:48:59.0000000Z Current runner version: '2.329.0'\n" +
+			"2025-10-25T13:49:01.0000000Z ##[group]Run golangci-lint run\n" +
+			"2025-10-25T13:49:05.0000000Z ##[endgroup]\n",
+	}
+	for path, content := range files {
+		if err := afero.WriteFile(fs, path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c := New(fs, nil)
+	jobs := c.ReadLogFiles(slog.New(slog.DiscardHandler), &Input{Config: &config.Config{}}, []string{"/logs/run.log", "/logs/1_lint.txt", "/logs/missing.txt"})
+	names := make([]string, len(jobs))
+	for i, job := range jobs {
+		names[i] = job.Job.GetName()
+	}
+	if diff := cmp.Diff([]string{"build", "test", "lint"}, names); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -10,8 +10,10 @@ import (
 	"maps"
 	"path/filepath"
 	"slices"
+	"strings"
 
 	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/cache"
 	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
 	"github.com/suzuki-shunsuke/ghaperf/pkg/parser"
 	"github.com/suzuki-shunsuke/ghaperf/pkg/xdg"
@@ -43,42 +45,112 @@ func (r *Collector) getJobsAndLogs(ctx context.Context, logger *slog.Logger, inp
 	if err != nil {
 		return nil, fmt.Errorf("get jobs: %w", err)
 	}
-	jobM := make(map[string]*Job, len(jobs))
-	for _, job := range jobs {
-		name := input.Config.NormalizeJobName(job.GetName())
-		if !input.Config.Include(job.GetName()) {
-			continue
-		}
-		jobM[job.GetName()] = &Job{
-			Job:            job,
-			NormalizedName: name,
-		}
+	jobM := newJobMap(input, jobs)
+	if run.GetStatus() != statusCompleted {
+		// The log archive of a workflow run is available only after the workflow run completes,
+		// so logs of completed jobs are downloaded one by one.
+		r.getJobLogs(ctx, logger, input, jobM)
+		return jobList(jobM), nil
 	}
 	logCacheDir := xdg.RunLogCache(input.CacheDir, input.RepoOwner, input.RepoName, run.GetID(), run.GetRunAttempt())
 	logCacheFile := xdg.RunLogCacheFile(input.CacheDir, input.RepoOwner, input.RepoName, run.GetID(), run.GetRunAttempt())
-	if f, err := afero.Exists(r.fs, logCacheFile); err == nil && f {
+	if f, err := afero.Exists(r.fs, logCacheFile); err == nil && f && !input.Refresh {
 		// exist cache
 		if err := r.readCachedLog(logger, logCacheDir, jobM); err != nil {
 			return nil, fmt.Errorf("read cached logs: %w", err)
 		}
-		return slices.Collect(maps.Values(jobM)), nil
+		return jobList(jobM), nil
+	}
+
+	if input.Offline {
+		logger.Warn("logs of the workflow run aren't cached, so log groups are excluded from the report", "run_id", run.GetID(), "run_attempt", run.GetRunAttempt())
+		return jobList(jobM), nil
+	}
+
+	unlock, err := cache.Lock(ctx, r.fs, logCacheDir)
+	if err != nil {
+		return nil, fmt.Errorf("lock cached workflow run log dir: %w", err)
+	}
+	defer unlock()
+	if f, err := afero.Exists(r.fs, logCacheFile); err == nil && f && !input.Refresh {
+		// another process cached logs while this process was waiting for the lock
+		if err := r.readCachedLog(logger, logCacheDir, jobM); err != nil {
+			return nil, fmt.Errorf("read cached logs: %w", err)
+		}
+		return jobList(jobM), nil
 	}
 
+	if input.Refresh {
+		// remove stale log files which aren't included in the new log archive
+		if err := r.fs.RemoveAll(logCacheDir); err != nil {
+			return nil, fmt.Errorf("remove cached workflow run log dir: %w", err)
+		}
+		if err := r.fs.Remove(logCacheFile); err != nil && !errors.Is(err, afero.ErrFileNotFound) {
+			return nil, fmt.Errorf("remove cached workflow run log file: %w", err)
+		}
+	}
 	if err := r.fs.MkdirAll(logCacheDir, dirPermission); err != nil {
 		return nil, fmt.Errorf("make dirs for cached workflow run log dir: %w", err)
 	}
-	files, err := r.gh.GetWorkflowRunLogs(ctx, input.RepoOwner, input.RepoName, run.GetID(), run.GetRunAttempt())
+	logs, err := r.gh.GetWorkflowRunLogs(ctx, input.RepoOwner, input.RepoName, run.GetID(), run.GetRunAttempt())
 	if err != nil {
-		return slices.Collect(maps.Values(jobM)), err
+		return jobList(jobM), err
+	}
+	defer func() {
+		if err := logs.Close(); err != nil {
+			slogerr.WithError(logger, err).Warn("remove the downloaded workflow run logs")
+		}
+	}()
+	r.cacheAndParseLogs(logger, logs.Files, logCacheDir, logCacheFile, jobM)
+	return jobList(jobM), nil
+}
+
+// newJobMap returns a map of job names and jobs excluding jobs filtered by the configuration.
+func newJobMap(input *Input, jobs []*github.WorkflowJob) map[string]*Job {
+	jobM := make(map[string]*Job, len(jobs))
+	for _, job := range jobs {
+		name := input.Config.NormalizeJobName(job.GetName())
+		if !input.Config.Include(job.GetName()) {
+			continue
+		}
+		jobM[job.GetName()] = &Job{
+			Job:            job,
+			NormalizedName: name,
+		}
+	}
+	return jobM
+}
+
+func jobList(jobM map[string]*Job) []*Job {
+	return slices.Collect(maps.Values(jobM))
+}
+
+// getJobLogs gets logs of completed jobs and marks running jobs as partial.
+func (r *Collector) getJobLogs(ctx context.Context, logger *slog.Logger, input *Input, jobM map[string]*Job) {
+	for _, job := range jobM {
+		logArgs := []any{"job_id", job.Job.GetID(), "job_name", job.Job.GetName(), "job_status", job.Job.GetStatus()}
+		if job.Job.GetStatus() != statusCompleted {
+			job.Partial = true
+			continue
+		}
+		if job.Job.GetConclusion() == "skipped" {
+			continue
+		}
+		log, err := r.GetJobLog(ctx, input, job.Job.GetID())
+		if err != nil {
+			slogerr.WithError(logger, err).Error("get a job log", logArgs...)
+			job.LogHasGone = errors.Is(err, github.ErrLogHasGone)
+			continue
+		}
+		WarnLineErrors(logger, log, logArgs...)
+		job.Groups = log.Groups
 	}
-	r.cacheAndParseLogs(logger, files, logCacheDir, logCacheFile, jobM)
-	return slices.Collect(maps.Values(jobM)), nil
 }
 
 func (r *Collector) cacheAndParseLogs(logger *slog.Logger, files []*zip.File, logCacheDir, logCacheFile string, jobM map[string]*Job) {
 	allCached := true
 	for _, file := range files {
-		cached, err := r.cacheAndParseLog(filepath.Join(logCacheDir, file.Name), file, jobM) //nolint:gosec
+		cached, err := r.cacheAndParseLog(logger, filepath.Join(logCacheDir, file.Name+cache.CompressedExt), file, jobM) //nolint:gosec
 		if err != nil {
 			slogerr.WithError(logger, err).Error("parse a cached log file", "file_name", file.Name)
 		}
@@ -87,13 +159,13 @@ func (r *Collector) cacheAndParseLogs(logger *slog.Logger, files []*zip.File, lo
 		}
 	}
 	if allCached {
-		if err := afero.WriteFile(r.fs, logCacheFile, []byte{}, filePermission); err != nil {
+		if err := cache.WriteFile(r.fs, logCacheFile, []byte{}); err != nil {
 			slogerr.WithError(logger, err).Error("write cached workflow run log file")
 		}
 	}
 }
 
-func (r *Collector) cacheAndParseLog(cachePath string, file *zip.File, jobM map[string]*Job) (bool, error) {
+func (r *Collector) cacheAndParseLog(logger *slog.Logger, cachePath string, file *zip.File, jobM map[string]*Job) (bool, error) {
 	if err := r.cacheLog(cachePath, file); err != nil {
 		return false, err
 	}
@@ -101,6 +173,7 @@ func (r *Collector) cacheAndParseLog(cachePath string, file *zip.File, jobM map[
 	if err != nil {
 		return true, err
 	}
+	WarnLineErrors(logger, log, "file_name", file.Name)
 	job, ok := jobM[log.JobName]
 	if !ok {
 		return true, nil
@@ -115,11 +188,28 @@ func (r *Collector) readCachedLog(logger *slog.Logger, logCacheDir string, jobM
 		return fmt.Errorf("read cached workflow run log dir: %w", err)
 	}
 	for _, info := range infos {
-		log, err := r.readLog(filepath.Join(logCacheDir, info.Name()))
+		if cache.IsTempFile(info.Name()) {
+			continue
+		}
+		path := filepath.Join(logCacheDir, info.Name())
+		if !strings.HasSuffix(path, cache.CompressedExt) {
+			// migrate the log file cached by old ghaperf without compression
+			dest, err := cache.Compress(r.fs, path)
+			if err != nil {
+				slogerr.WithError(logger, err).Warn("compress a cached log file", "file_name", info.Name())
+			} else {
+				path = dest
+			}
+		}
+		log, err := r.readLog(path)
 		if err != nil {
 			slogerr.WithError(logger, err).Error("parse a cached log file", "file_name", info.Name())
+			if cache.IsCorrupt(err) {
+				r.removeBrokenLog(logger, logCacheDir, path)
+			}
 			continue
 		}
+		WarnLineErrors(logger, log, "file_name", info.Name())
 		job, ok := jobM[log.JobName]
 		if !ok {
 			continue
@@ -129,13 +219,23 @@ func (r *Collector) readCachedLog(logger *slog.Logger, logCacheDir string, jobM
 	return nil
 }
 
+// removeBrokenLog removes a broken log file and the file which marks that logs are cached,
+// so logs of the workflow run are downloaded again next time.
+func (r *Collector) removeBrokenLog(logger *slog.Logger, logCacheDir, path string) {
+	for _, p := range []string{path, filepath.Join(filepath.Dir(logCacheDir), "cached.txt")} {
+		if err := r.fs.Remove(p); err != nil && !errors.Is(err, afero.ErrFileNotFound) {
+			slogerr.WithError(logger, err).Warn("remove a broken cache file", "path", p)
+		}
+	}
+}
+
 func (r *Collector) cacheLog(cachePath string, file *zip.File) error {
 	f, err := file.Open()
 	if err != nil {
 		return fmt.Errorf("open a log file from workflow run logs: %w", err)
 	}
 	defer f.Close()
-	cacheFile, err := r.fs.Create(cachePath)
+	cacheFile, err := cache.Create(r.fs, cachePath)
 	if err != nil {
 		return fmt.Errorf("create a cached log file: %w", err)
 	}
@@ -144,11 +244,14 @@ func (r *Collector) cacheLog(cachePath string, file *zip.File) error {
 	if err := copySafe(cacheFile, f); err != nil {
 		return fmt.Errorf("cache a log file: %w", err)
 	}
+	if err := cacheFile.Commit(); err != nil {
+		return fmt.Errorf("cache a log file: %w", err)
+	}
 	return nil
 }
 
 func (r *Collector) readLog(cachePath string) (*parser.Log, error) {
-	f, err := r.fs.Open(cachePath)
+	f, err := cache.Open(r.fs, cachePath)
 	if err != nil {
 		return nil, fmt.Errorf("open a cached log file: %w", err)
 	}
@@ -172,7 +275,7 @@ func (r *Collector) cacheJobIDs(jobs []*github.WorkflowJob, cachePath string) er
 	if err := r.fs.MkdirAll(filepath.Dir(cachePath), dirPermission); err != nil {
 		return fmt.Errorf("make dirs for cached job IDs file: %w", err)
 	}
-	if err := afero.WriteFile(r.fs, cachePath, b, filePermission); err != nil {
+	if err := cache.WriteFile(r.fs, cachePath, b); err != nil {
 		return fmt.Errorf("write cached job IDs file: %w", err)
 	}
 	return nil
@@ -186,28 +289,26 @@ func (r *Collector) cacheRun(workflowRun *github.WorkflowRun, cachePath string)
 	if err := r.fs.MkdirAll(filepath.Dir(cachePath), dirPermission); err != nil {
 		return fmt.Errorf("make dirs for cached workflow run file: %w", err)
 	}
-	if err := afero.WriteFile(r.fs, cachePath, b, filePermission); err != nil {
+	if err := cache.WriteFile(r.fs, cachePath, b); err != nil {
 		return fmt.Errorf("write cached workflow run file: %w", err)
 	}
 	return nil
 }
 
 func (r *Collector) getRun(ctx context.Context, logger *slog.Logger, input *Input, runID int64, attempt int) (*github.WorkflowRun, error) {
-	var cachePath string
-	if attempt > 0 {
-		cachePath = xdg.RunCache(input.CacheDir, input.RepoOwner, input.RepoName, runID, attempt)
-		runB, err := afero.ReadFile(r.fs, cachePath)
+	if attempt == 0 && input.Offline {
+		// the latest attempt can't be got by the GitHub API
+		attempt = r.latestCachedAttempt(input, runID)
+	}
+	if attempt > 0 && !input.Refresh {
+		run := &github.WorkflowRun{}
+		found, err := r.readCachedJSON(logger, xdg.RunCache(input.CacheDir, input.RepoOwner, input.RepoName, runID, attempt), run)
 		if err != nil {
-			if !errors.Is(err, afero.ErrFileNotFound) {
-				slogerr.WithError(logger, err).Error("read cached workflow run file")
-			}
-			return r.getAndCacheRun(ctx, logger, input, runID, attempt)
+			slogerr.WithError(logger, err).Error("read cached workflow run file")
 		}
-		run := &github.WorkflowRun{}
-		if err := json.Unmarshal(runB, run); err != nil {
-			return nil, fmt.Errorf("unmarshal cached workflow run file: %w", err)
+		if found {
+			return run, nil
 		}
-		return run, nil
 	}
 	return r.getAndCacheRun(ctx, logger, input, runID, attempt)
 }
@@ -219,7 +320,7 @@ func (r *Collector) getAndCacheRun(ctx context.Context, logger *slog.Logger, inp
 	}
 	if run.GetStatus() == statusCompleted {
 		// cache workflow run
-		if err := r.cacheRun(run, xdg.RunCache(input.CacheDir, input.RepoOwner, input.RepoName, input.RunID, run.GetRunAttempt())); err != nil {
+		if err := r.cacheRun(run, xdg.RunCache(input.CacheDir, input.RepoOwner, input.RepoName, runID, run.GetRunAttempt())); err != nil {
 			slogerr.WithError(logger, err).Error("cache a workflow run")
 		}
 	}
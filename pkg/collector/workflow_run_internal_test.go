@@ -0,0 +1,119 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/config"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/parser"
+)
+
+var errNotImplemented = errors.New("not implemented")
+
+// fakeGitHub returns jobs and job logs of a workflow run in progress.
+type fakeGitHub struct {
+	jobs []*github.WorkflowJob
+	logs map[int64]string
+}
+
+func (f *fakeGitHub) GetWorkflowJobByID(_ context.Context, _, _ string, _ int64) (*github.WorkflowJob, error) {
+	return nil, errNotImplemented
+}
+
+func (f *fakeGitHub) GetWorkflowJobLogs(_ context.Context, _, _ string, jobID int64) (io.ReadCloser, error) {
+	log, ok := f.logs[jobID]
+	if !ok {
+		return nil, errNotImplemented
+	}
+	return io.NopCloser(strings.NewReader(log)), nil
+}
+
+func (f *fakeGitHub) GetWorkflowRunByID(_ context.Context, _, _ string, _ int64, _ int) (*github.WorkflowRun, error) {
+	return nil, errNotImplemented
+}
+
+func (f *fakeGitHub) ListWorkflowJobs(_ context.Context, _, _ string, _ int64, _ int) ([]*github.WorkflowJob, error) {
+	return f.jobs, nil
+}
+
+func (f *fakeGitHub) ListWorkflowRuns(_ context.Context, _, _ string, _ string, _ int, _ *github.ListWorkflowRunsOptions) ([]*github.WorkflowRun, error) {
+	return nil, errNotImplemented
+}
+
+func (f *fakeGitHub) GetWorkflowRunLogs(_ context.Context, _, _ string, _ int64, _ int) (*github.RunLogs, error) {
+	// The log archive isn't available until the workflow run completes
+	return nil, errNotImplemented
+}
+
+func TestCollector_getJobsAndLogs_inProgress(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	str := func(s string) *string { return &s }
+	ts := func(d time.Duration) *github.Timestamp {
+		return &github.Timestamp{Time: start.Add(d)}
+	}
+	id := func(i int64) *int64 { return &i }
+	gh := &fakeGitHub{
+		jobs: []*github.WorkflowJob{
+			{ID: id(1), Name: str("build"), Status: str("completed"), Conclusion: str("success"), StartedAt: ts(0), CompletedAt: ts(time.Minute)},
+			{ID: id(2), Name: str("test"), Status: str("in_progress"), StartedAt: ts(0)},
+			{ID: id(3), Name: str("lint"), Status: str("completed"), Conclusion: str("skipped")},
+		},
+		logs: map[int64]string{
+			1: "2025-10-01T00:00:10.0000000Z ##[group]Run make build\n" +
+				"2025-10-01T00:00:50.0000000Z ##[endgroup]\n",
+		},
+	}
+	c := New(afero.NewMemMapFs(), gh)
+	input := &Input{
+		CacheDir:  "/cache",
+		RepoOwner: "suzuki-shunsuke",
+		RepoName:  "ghaperf",
+		Config:    &config.Config{},
+	}
+	id100 := int64(100)
+	attempt := 1
+	run := &github.WorkflowRun{ID: &id100, RunAttempt: &attempt, Status: str("in_progress")}
+	jobs, err := c.getJobsAndLogs(t.Context(), slog.New(slog.DiscardHandler), input, run)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jobM := make(map[string]*Job, len(jobs))
+	for _, job := range jobs {
+		jobM[job.Job.GetName()] = job
+	}
+	if len(jobM) != 3 {
+		t.Fatalf("len(jobs) = %d, want 3", len(jobM))
+	}
+	if job := jobM["build"]; job.Partial || !slices.ContainsFunc(job.Groups, func(g *parser.Group) bool { return g.Name == "Run make build" }) {
+		t.Errorf("the log of the completed job should be parsed: partial=%v, groups=%d", job.Partial, len(job.Groups))
+	}
+	if job := jobM["test"]; !job.Partial || len(job.Groups) != 0 {
+		t.Errorf("the running job should be partial without logs: partial=%v, groups=%d", job.Partial, len(job.Groups))
+	}
+	if job := jobM["lint"]; job.Partial || len(job.Groups) != 0 {
+		t.Errorf("the log of the skipped job shouldn't be downloaded: partial=%v, groups=%d", job.Partial, len(job.Groups))
+	}
+}
+
+func TestNewJobMap(t *testing.T) {
+	t.Parallel()
+	str := func(s string) *string { return &s }
+	jobs := []*github.WorkflowJob{
+		{Name: str("build"), Status: str("completed"), Conclusion: str("success")},
+		{Name: str("test"), Status: str("completed"), Conclusion: str("failure")},
+	}
+	// jobs are filtered by the conclusion in views, so --attempts and --by-conclusion can analyze all jobs
+	jobM := newJobMap(&Input{Config: &config.Config{JobConclusions: []string{"success"}}}, jobs)
+	if len(jobM) != 2 {
+		t.Errorf("jobs shouldn't be filtered by the conclusion: %d", len(jobM))
+	}
+}
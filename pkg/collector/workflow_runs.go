@@ -16,16 +16,23 @@ type WorkflowRun struct {
 }
 
 func (r *Collector) ListRuns(ctx context.Context, logger *slog.Logger, input *Input, maxCount int) ([]*WorkflowRun, error) {
-	runs, err := r.gh.ListWorkflowRuns(ctx, input.RepoOwner, input.RepoName, input.WorkflowName, maxCount, input.ListWorkflowRunsOptions)
+	runs, missing, err := r.listRuns(ctx, logger, input, maxCount)
 	if err != nil {
-		return nil, fmt.Errorf("list workflow runs: %w", err)
+		return nil, err
 	}
 	arr := make([]*WorkflowRun, 0, len(runs))
 	for _, run := range runs {
 		logArgs := []any{"run_id", run.GetID(), "run_attempt", run.GetRunAttempt()}
+		if !input.Config.IncludeRunConclusion(run.GetConclusion()) {
+			logger.Debug("skip a workflow run by the conclusion", append(logArgs, "run_conclusion", run.GetConclusion())...)
+			continue
+		}
 		jobs, err := r.getJobsAndLogs(ctx, logger, input, run)
 		if err != nil {
 			slogerr.WithError(logger, err).Error("get jobs and logs", logArgs...)
+			if input.Offline {
+				missing = append(missing, &runRef{ID: run.GetID(), Attempt: run.GetRunAttempt()})
+			}
 			continue
 		}
 		arr = append(arr, &WorkflowRun{
@@ -33,5 +40,34 @@ func (r *Collector) ListRuns(ctx context.Context, logger *slog.Logger, input *In
 			Jobs: jobs,
 		})
 	}
+	reportMissingRuns(logger, input, missing)
 	return arr, nil
 }
+
+// listRuns lists workflow runs by the GitHub API and caches the list as the index for offline mode.
+// In offline mode, workflow runs are listed from the index and workflow runs which aren't cached are returned as missing runs.
+func (r *Collector) listRuns(ctx context.Context, logger *slog.Logger, input *Input, maxCount int) ([]*github.WorkflowRun, []*runRef, error) {
+	if input.Offline {
+		return r.listIndexedRuns(logger, input, maxCount)
+	}
+	runs, err := r.gh.ListWorkflowRuns(ctx, input.RepoOwner, input.RepoName, input.WorkflowName, maxCount, input.ListWorkflowRunsOptions)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list workflow runs: %w", err)
+	}
+	if err := r.cacheRunIndex(logger, input, runs); err != nil {
+		slogerr.WithError(logger, err).Warn("cache the index of workflow runs")
+	}
+	return runs, nil, nil
+}
+
+// reportMissingRuns warns workflow runs which are excluded from the report because they aren't cached.
+func reportMissingRuns(logger *slog.Logger, input *Input, missing []*runRef) {
+	if len(missing) == 0 {
+		return
+	}
+	urls := make([]string, len(missing))
+	for i, ref := range missing {
+		urls[i] = fmt.Sprintf("https://github.com/%s/%s/actions/runs/%d/attempts/%d", input.RepoOwner, input.RepoName, ref.ID, ref.Attempt)
+	}
+	logger.Warn("workflow runs aren't cached, so they are excluded from the report. Run ghaperf without --offline to cache them", "count", len(missing), "runs", urls)
+}
@@ -0,0 +1,128 @@
+package comment
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+)
+
+// Marker is a hidden marker to find comments posted by ghaperf.
+const Marker = "<!-- ghaperf -->"
+
+// maxBodyLength is the maximum length of comments.
+// GitHub rejects comments longer than this.
+const maxBodyLength = 65536
+
+const truncatedNote = "\n\n> [!WARNING]\n> The report is truncated because it exceeds the size limit of comments. Please run ghaperf locally to see the full report.\n"
+
+type GitHub interface {
+	ListIssueComments(ctx context.Context, owner, repo string, number int) ([]*github.IssueComment, error)
+	CreateIssueComment(ctx context.Context, owner, repo string, number int, body string) error
+	EditIssueComment(ctx context.Context, owner, repo string, commentID int64, body string) error
+}
+
+type Commenter struct {
+	gh GitHub
+}
+
+func New(gh GitHub) *Commenter {
+	return &Commenter{
+		gh: gh,
+	}
+}
+
+// Post posts the report to the pull request.
+// If ghaperf already posted a comment to the pull request, the comment is updated.
+func (c *Commenter) Post(ctx context.Context, logger *slog.Logger, owner, repo string, number int, report string) error {
+	body := Body(report)
+	comments, err := c.gh.ListIssueComments(ctx, owner, repo, number)
+	if err != nil {
+		return fmt.Errorf("list pull request comments: %w", err)
+	}
+	for _, comment := range comments {
+		if !strings.HasPrefix(comment.GetBody(), Marker) {
+			continue
+		}
+		if err := c.gh.EditIssueComment(ctx, owner, repo, comment.GetID(), body); err != nil {
+			return fmt.Errorf("update a pull request comment: %w", err)
+		}
+		logger.Info("updated a pull request comment", "pr", number, "comment_id", comment.GetID())
+		return nil
+	}
+	if err := c.gh.CreateIssueComment(ctx, owner, repo, number, body); err != nil {
+		return fmt.Errorf("create a pull request comment: %w", err)
+	}
+	logger.Info("created a pull request comment", "pr", number)
+	return nil
+}
+
+// Body returns the comment body with the marker.
+// If the report is too long, the report is truncated at the last section boundary
+// and HTML tags and code blocks left open are closed.
+func Body(report string) string {
+	body := Marker + "\n" + report
+	if len(body) <= maxBodyLength {
+		return body
+	}
+	body = body[:maxBodyLength-len(truncatedNote)]
+	if idx := lastSectionIndex(body); idx > len(Marker) {
+		body = body[:idx]
+	} else {
+		body = body[:max(strings.LastIndex(body, "\n"), len(Marker))]
+	}
+	for {
+		closing := closeBlocks(body)
+		if len(body)+len(closing)+len(truncatedNote) <= maxBodyLength {
+			return body + closing + truncatedNote
+		}
+		body = body[:max(strings.LastIndex(body, "\n"), len(Marker))]
+	}
+}
+
+// lastSectionIndex returns the index of the last Markdown heading of the body.
+func lastSectionIndex(body string) int {
+	return max(strings.LastIndex(body, "\n## "), strings.LastIndex(body, "\n### "))
+}
+
+var tagPattern = regexp.MustCompile(`(?i)<(/?)(details|summary|table)\b[^>]*>`)
+
+// closeBlocks returns the closing code fence and HTML tags of blocks which aren't closed in the body.
+func closeBlocks(body string) string {
+	var opened []string
+	inCode := false
+	for line := range strings.Lines(body) {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			continue
+		}
+		for _, m := range tagPattern.FindAllStringSubmatch(line, -1) {
+			name := strings.ToLower(m[2])
+			if m[1] == "" {
+				opened = append(opened, name)
+				continue
+			}
+			for i, o := range slices.Backward(opened) {
+				if o == name {
+					opened = opened[:i]
+					break
+				}
+			}
+		}
+	}
+	closing := ""
+	if inCode {
+		closing = "\n```"
+	}
+	for _, name := range slices.Backward(opened) {
+		closing += "\n</" + name + ">"
+	}
+	return closing
+}
@@ -0,0 +1,177 @@
+package comment
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+)
+
+type fakeComment struct {
+	ID   int64  `json:"id"`
+	Body string `json:"body"`
+}
+
+// fakeIssues is a local fake of GitHub Issues API.
+type fakeIssues struct {
+	mu       sync.Mutex
+	comments []*fakeComment
+}
+
+func (f *fakeIssues) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/{owner}/{repo}/issues/{number}/comments", func(w http.ResponseWriter, _ *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(f.comments)
+	})
+	mux.HandleFunc("POST /repos/{owner}/{repo}/issues/{number}/comments", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		c := &fakeComment{}
+		if err := json.NewDecoder(r.Body).Decode(c); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		c.ID = int64(len(f.comments) + 1)
+		f.comments = append(f.comments, c)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(c)
+	})
+	mux.HandleFunc("PATCH /repos/{owner}/{repo}/issues/comments/{id}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		c := &fakeComment{}
+		if err := json.NewDecoder(r.Body).Decode(c); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for _, comment := range f.comments {
+			if comment.ID == id {
+				comment.Body = c.Body
+				_ = json.NewEncoder(w).Encode(comment)
+				return
+			}
+		}
+		http.NotFound(w, r)
+	})
+	return mux
+}
+
+func TestCommenter_Post(t *testing.T) {
+	t.Parallel()
+	fake := &fakeIssues{
+		comments: []*fakeComment{
+			{ID: 1, Body: "LGTM"},
+		},
+	}
+	srv := httptest.NewServer(fake.handler())
+	t.Cleanup(srv.Close)
+	gh, err := github.New(t.Context(), slog.New(slog.DiscardHandler), &github.InputNew{
+		AccessToken: "dummy",
+		BaseURL:     srv.URL + "/",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	commenter := New(gh)
+	logger := slog.New(slog.DiscardHandler)
+
+	// create a comment
+	if err := commenter.Post(t.Context(), logger, "suzuki-shunsuke", "ghaperf", 1, "first report"); err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.comments) != 2 || fake.comments[1].Body != Marker+"\nfirst report" {
+		t.Fatalf("a comment should be created: %+v", fake.comments)
+	}
+	// update the comment
+	if err := commenter.Post(t.Context(), logger, "suzuki-shunsuke", "ghaperf", 1, "second report"); err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.comments) != 2 || fake.comments[1].Body != Marker+"\nsecond report" {
+		t.Fatalf("the comment should be updated: %+v", fake.comments)
+	}
+	if fake.comments[0].Body != "LGTM" {
+		t.Fatalf("other comments should not be updated: %+v", fake.comments[0])
+	}
+}
+
+func TestBody(t *testing.T) {
+	t.Parallel()
+	if body := Body("report"); body != Marker+"\nreport" {
+		t.Errorf("Body() = %q", body)
+	}
+	long := strings.Repeat("| job | 1m0s |\n", maxBodyLength/10)
+	body := Body(long)
+	if len(body) > maxBodyLength {
+		t.Errorf("len(Body()) = %d, want <= %d", len(body), maxBodyLength)
+	}
+	if !strings.HasPrefix(body, Marker) || !strings.HasSuffix(body, truncatedNote) {
+		t.Errorf("the truncated body should have the marker and the note")
+	}
+	if !strings.HasSuffix(strings.TrimSuffix(body, truncatedNote), "| job | 1m0s |") {
+		t.Errorf("the report should be truncated at the end of a line")
+	}
+}
+
+func TestBody_truncateBlocks(t *testing.T) {
+	t.Parallel()
+	section := func(name string, rows int) string {
+		return "## " + name + "\n\n<details>\n<summary>jobs</summary>\n\n<table>\n" +
+			strings.Repeat("<tr><td>job</td><td>1m0s</td></tr>\n", rows) + "</table>\n\n</details>\n\n"
+	}
+	tests := []struct {
+		name   string
+		report string
+		suffix string
+	}{
+		{
+			name:   "section boundary",
+			report: section("a", 1000) + section("b", 1000) + section("c", 1000),
+			suffix: "</table>\n\n</details>\n",
+		},
+		{
+			name:   "a large section",
+			report: section("a", 3000),
+			suffix: "<tr><td>job</td><td>1m0s</td></tr>\n</table>\n</details>",
+		},
+		{
+			name:   "code block",
+			report: "## Hangs\n\n```\n" + strings.Repeat("<table> log\n", 10000),
+			suffix: "<table> log\n```",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if len(tt.report) <= maxBodyLength {
+				t.Fatalf("the report should be longer than %d: %d", maxBodyLength, len(tt.report))
+			}
+			body := Body(tt.report)
+			if len(body) > maxBodyLength {
+				t.Errorf("len(Body()) = %d, want <= %d", len(body), maxBodyLength)
+			}
+			if !strings.HasPrefix(body, Marker+"\n") || !strings.HasSuffix(body, truncatedNote) {
+				t.Fatal("the truncated body should have the marker and the note")
+			}
+			report := strings.TrimSuffix(body, truncatedNote)
+			if !strings.HasSuffix(report, tt.suffix) {
+				t.Errorf("the truncated report should end with %q: %q", tt.suffix, report[len(report)-100:])
+			}
+			if s := closeBlocks(report); s != "" {
+				t.Errorf("blocks should be closed: %q", s)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// conclusions are conclusions of jobs and workflow runs.
+// https://docs.github.com/en/rest/actions/workflow-runs
+var conclusions = []string{ //nolint:gochecknoglobals
+	"success",
+	"failure",
+	"cancelled",
+	"skipped",
+	"timed_out",
+	"action_required",
+	"neutral",
+	"stale",
+}
+
+var errInvalidConclusion = errors.New("invalid conclusion")
+
+// ValidateConclusions validates conclusions of jobs and workflow runs.
+func ValidateConclusions(arr []string) error {
+	for _, conclusion := range arr {
+		if !slices.Contains(conclusions, conclusion) {
+			return slogerr.With(errInvalidConclusion, "conclusion", conclusion) //nolint:wrapcheck
+		}
+	}
+	return nil
+}
+
+// IncludeJobConclusion returns true if jobs with the conclusion should be analyzed.
+func (c *Config) IncludeJobConclusion(conclusion string) bool {
+	return len(c.JobConclusions) == 0 || slices.Contains(c.JobConclusions, conclusion)
+}
+
+// IncludeRunConclusion returns true if workflow runs with the conclusion should be analyzed.
+func (c *Config) IncludeRunConclusion(conclusion string) bool {
+	return len(c.RunConclusions) == 0 || slices.Contains(c.RunConclusions, conclusion)
+}
@@ -0,0 +1,55 @@
+package config
+
+import "testing"
+
+func TestConfig_IncludeConclusion(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		cfg        *Config
+		conclusion string
+		includeJob bool
+		includeRun bool
+	}{
+		{name: "no filter", cfg: &Config{}, conclusion: "failure", includeJob: true, includeRun: true},
+		{
+			name:       "included",
+			cfg:        &Config{JobConclusions: []string{"success", "failure"}, RunConclusions: []string{"failure"}},
+			conclusion: "failure",
+			includeJob: true,
+			includeRun: true,
+		},
+		{
+			name:       "excluded",
+			cfg:        &Config{JobConclusions: []string{"success"}, RunConclusions: []string{"success"}},
+			conclusion: "cancelled",
+		},
+		{
+			name:       "only jobs are filtered",
+			cfg:        &Config{JobConclusions: []string{"success"}},
+			conclusion: "failure",
+			includeRun: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if f := tt.cfg.IncludeJobConclusion(tt.conclusion); f != tt.includeJob {
+				t.Errorf("IncludeJobConclusion(%s) = %v, want %v", tt.conclusion, f, tt.includeJob)
+			}
+			if f := tt.cfg.IncludeRunConclusion(tt.conclusion); f != tt.includeRun {
+				t.Errorf("IncludeRunConclusion(%s) = %v, want %v", tt.conclusion, f, tt.includeRun)
+			}
+		})
+	}
+}
+
+func TestValidateConclusions(t *testing.T) {
+	t.Parallel()
+	if err := ValidateConclusions([]string{"success", "timed_out"}); err != nil {
+		t.Errorf("valid conclusions are rejected: %v", err)
+	}
+	if err := ValidateConclusions([]string{"success", "failed"}); err == nil {
+		t.Error("an invalid conclusion should be rejected")
+	}
+}
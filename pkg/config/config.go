@@ -14,6 +14,9 @@ type Config struct {
 	JobNames         []*regexp.Regexp
 	ExcludedJobNames []*regexp.Regexp
 	JobNameMappings  map[*regexp.Regexp]string
+	RunnerCosts      map[string]float64
+	JobConclusions   []string
+	RunConclusions   []string
 }
 
 type RawConfig struct {
@@ -23,6 +26,12 @@ type RawConfig struct {
 	ExcludedJobNames []string `json:"excluded_job_names,omitempty" yaml:"excluded_job_names,omitempty"`
 	// original job name regular expression => normalized job name
 	JobNameMappings map[string]string `json:"job_name_mappings,omitempty" yaml:"job_name_mappings,omitempty"`
+	// runner label => per-minute rate (USD)
+	RunnerCosts map[string]float64 `json:"runner_costs,omitempty" yaml:"runner_costs,omitempty"`
+	// conclusions of jobs to analyze (e.g. success)
+	JobConclusions []string `json:"job_conclusions,omitempty" yaml:"job_conclusions,omitempty"`
+	// conclusions of workflow runs to analyze (e.g. success)
+	RunConclusions []string `json:"run_conclusions,omitempty" yaml:"run_conclusions,omitempty"`
 }
 
 func (c *Config) Include(name string) bool {
@@ -87,6 +96,15 @@ func Read(fs afero.Fs, path string, cfg *Config) error {
 		}
 		cfg.JobNameMappings[re] = mapped
 	}
+	cfg.RunnerCosts = rCfg.RunnerCosts
+	if err := ValidateConclusions(rCfg.JobConclusions); err != nil {
+		return fmt.Errorf("validate job_conclusions: %w", err)
+	}
+	cfg.JobConclusions = rCfg.JobConclusions
+	if err := ValidateConclusions(rCfg.RunConclusions); err != nil {
+		return fmt.Errorf("validate run_conclusions: %w", err)
+	}
+	cfg.RunConclusions = rCfg.RunConclusions
 	return nil
 }
 
@@ -0,0 +1,46 @@
+package config
+
+// defaultRunnerCosts is the per-minute rate (USD) of GitHub-hosted standard runners.
+// The OS multipliers are already applied to the rates.
+// https://docs.github.com/en/billing/reference/actions-minute-multipliers
+var defaultRunnerCosts = map[string]float64{ //nolint:gochecknoglobals
+	"ubuntu-latest":       0.008,
+	"ubuntu-24.04":        0.008,
+	"ubuntu-22.04":        0.008,
+	"ubuntu-24.04-arm":    0.005,
+	"ubuntu-22.04-arm":    0.005,
+	"windows-latest":      0.016,
+	"windows-2025":        0.016,
+	"windows-2022":        0.016,
+	"windows-11-arm":      0.010,
+	"macos-latest":        0.08,
+	"macos-26":            0.08,
+	"macos-15":            0.08,
+	"macos-14":            0.08,
+	"macos-13":            0.08,
+	"macos-latest-large":  0.12,
+	"macos-15-large":      0.12,
+	"macos-14-large":      0.12,
+	"macos-13-large":      0.12,
+	"macos-latest-xlarge": 0.16,
+	"macos-15-xlarge":     0.16,
+	"macos-14-xlarge":     0.16,
+	"macos-13-xlarge":     0.16,
+}
+
+// RunnerCost returns the per-minute rate (USD) of a runner.
+// Rates in the configuration file take precedence over the default rates.
+// The second return value is false if no rate is found for the labels.
+func (c *Config) RunnerCost(labels []string) (float64, bool) {
+	for _, label := range labels {
+		if rate, ok := c.RunnerCosts[label]; ok {
+			return rate, true
+		}
+	}
+	for _, label := range labels {
+		if rate, ok := defaultRunnerCosts[label]; ok {
+			return rate, true
+		}
+	}
+	return 0, false
+}
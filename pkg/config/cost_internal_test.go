@@ -0,0 +1,34 @@
+package config
+
+import "testing"
+
+func TestConfig_RunnerCost(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{
+		RunnerCosts: map[string]float64{
+			"ubuntu-latest": 0.004,
+			"self-hosted":   0,
+		},
+	}
+	tests := []struct {
+		name   string
+		labels []string
+		rate   float64
+		ok     bool
+	}{
+		{name: "configured rate takes precedence", labels: []string{"ubuntu-latest"}, rate: 0.004, ok: true},
+		{name: "default rate", labels: []string{"macos-15"}, rate: 0.08, ok: true},
+		{name: "configured rate of any label first", labels: []string{"windows-latest", "self-hosted"}, rate: 0, ok: true},
+		{name: "unknown", labels: []string{"my-runner"}},
+		{name: "no label"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rate, ok := cfg.RunnerCost(tt.labels)
+			if rate != tt.rate || ok != tt.ok {
+				t.Errorf("RunnerCost(%v) = %v, %v, want %v, %v", tt.labels, rate, ok, tt.rate, tt.ok)
+			}
+		})
+	}
+}
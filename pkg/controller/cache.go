@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/cache"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/view"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/xdg"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+var (
+	errCacheCommandRequired = errors.New("a subcommand of ghaperf cache must be specified: stats, prune, clear, verify, compress")
+	errBrokenCache          = errors.New("broken cache is found")
+)
+
+func (c *Controller) cache(inputRun *InputRun, arg *Arg) error {
+	if len(inputRun.Args) < 2 { //nolint:mnd
+		return errCacheCommandRequired
+	}
+	filter := &cache.Filter{}
+	if inputRun.Repo != "" {
+		repoOwner, repoName, err := validateRepo(inputRun.Repo)
+		if err != nil {
+			return err
+		}
+		filter.RepoOwner = repoOwner
+		filter.RepoName = repoName
+	}
+	cacheDir := xdg.CacheDir(arg.Getenv, arg.Home)
+	store := cache.New(arg.Fs, cacheDir)
+	viewer := view.New(arg.Stdout)
+	switch inputRun.Args[1] {
+	case "stats":
+		stats, err := store.Stats(filter)
+		if err != nil {
+			return fmt.Errorf("get stats of the cache: %w", err)
+		}
+		viewer.ShowCacheStats(cacheDir, stats)
+		return nil
+	case "prune":
+		opts, err := getPruneOptions(inputRun)
+		if err != nil {
+			return err
+		}
+		entries, err := store.Prune(filter, opts)
+		viewer.ShowPrunedCache(entries, opts.DryRun)
+		if err != nil {
+			return fmt.Errorf("prune the cache: %w", err)
+		}
+		return nil
+	case "clear":
+		if err := store.Clear(filter); err != nil {
+			return fmt.Errorf("clear the cache: %w", err)
+		}
+		return nil
+	case "compress":
+		count, saved, err := store.CompressAll(filter)
+		fmt.Fprintf(arg.Stdout, "Compressed %d log files (%s saved)\n", count, cache.FormatSize(saved))
+		if err != nil {
+			return fmt.Errorf("compress cached logs: %w", err)
+		}
+		return nil
+	case "verify":
+		issues, err := store.Verify(filter)
+		if err != nil {
+			return fmt.Errorf("verify the cache: %w", err)
+		}
+		if inputRun.Fix {
+			if err := store.Fix(issues); err != nil {
+				return fmt.Errorf("remove broken cache: %w", err)
+			}
+		}
+		viewer.ShowCacheIssues(issues, inputRun.Fix)
+		if len(issues) > 0 && !inputRun.Fix {
+			return slogerr.With(errBrokenCache, "count", len(issues)) //nolint:wrapcheck
+		}
+		return nil
+	default:
+		return slogerr.With(errUnknownCommand, "command", "cache "+inputRun.Args[1]) //nolint:wrapcheck
+	}
+}
+
+func getPruneOptions(inputRun *InputRun) (*cache.PruneOptions, error) {
+	opts := &cache.PruneOptions{
+		DryRun: inputRun.DryRun,
+	}
+	if inputRun.OlderThan == "" && inputRun.MaxSize == "" {
+		return nil, errors.New("ghaperf cache prune requires --older-than or --max-size")
+	}
+	if inputRun.OlderThan != "" {
+		d, err := time.ParseDuration(inputRun.OlderThan)
+		if err != nil {
+			return nil, fmt.Errorf("parse --older-than. See https://pkg.go.dev/time#ParseDuration: %w", err)
+		}
+		opts.OlderThan = d
+	}
+	if inputRun.MaxSize != "" {
+		size, err := cache.ParseSize(inputRun.MaxSize)
+		if err != nil {
+			return nil, fmt.Errorf("parse --max-size: %w", err)
+		}
+		if size == 0 {
+			return nil, errors.New("--max-size must be greater than 0. To remove all cache, use ghaperf cache clear")
+		}
+		opts.MaxSize = size
+	}
+	return opts, nil
+}
+
+// useTempCache makes ghaperf use a temporary cache directory, which is removed by the returned function.
+// So --no-cache neither reads nor writes the cache.
+func useTempCache(fs afero.Fs, input *collector.Input) (func(), error) {
+	dir, err := afero.TempDir(fs, "", "ghaperf-cache-")
+	if err != nil {
+		return nil, fmt.Errorf("create a temporary cache directory: %w", err)
+	}
+	input.CacheDir = dir
+	return func() {
+		fs.RemoveAll(dir) //nolint:errcheck
+	}, nil
+}
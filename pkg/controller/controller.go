@@ -1,7 +1,6 @@
 package controller
 
 import (
-	"archive/zip"
 	"context"
 	"io"
 
@@ -24,7 +23,7 @@ type GitHub interface {
 	GetWorkflowRunByID(ctx context.Context, owner, repo string, runID int64) (*github.WorkflowRun, error)
 	ListWorkflowJobs(ctx context.Context, owner, repo string, runID int64) ([]*github.WorkflowJob, error)
 	ListWorkflowRuns(ctx context.Context, owner, repo string, fileName string, maxCount int, opts *github.ListWorkflowRunsOptions) ([]*github.WorkflowRun, error)
-	GetWorkflowRunLogs(ctx context.Context, owner, repo string, runID int64, attempt int) ([]*zip.File, error)
+	GetWorkflowRunLogs(ctx context.Context, owner, repo string, runID int64, attempt int) (*github.RunLogs, error)
 }
 
 type InputNew struct{}
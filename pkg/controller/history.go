@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/history"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/view"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/xdg"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+var errInvalidBucket = errors.New("--bucket must be either day or week")
+
+func (c *Controller) history(inputRun *InputRun, arg *Arg) error {
+	if inputRun.Repo == "" {
+		return errors.New("--repo must be specified")
+	}
+	repoOwner, repoName, err := validateRepo(inputRun.Repo)
+	if err != nil {
+		return err
+	}
+	bucket := inputRun.Bucket
+	switch bucket {
+	case "":
+		bucket = view.BucketWeek
+	case view.BucketDay, view.BucketWeek:
+	default:
+		return slogerr.With(errInvalidBucket, "bucket", bucket) //nolint:wrapcheck
+	}
+	filter := &history.Filter{
+		JobName:   inputRun.HistoryJob,
+		StepName:  inputRun.HistoryStep,
+		GroupName: inputRun.HistoryGroup,
+	}
+	if inputRun.Since != "" {
+		d, err := time.ParseDuration(inputRun.Since)
+		if err != nil {
+			return fmt.Errorf("parse --since. See https://pkg.go.dev/time#ParseDuration: %w", err)
+		}
+		filter.Since = time.Now().Add(-d)
+	}
+	store := history.New(arg.Fs, xdg.DataDir(arg.Getenv, arg.Home))
+	samples, err := store.Query(repoOwner, repoName, filter)
+	if err != nil {
+		return fmt.Errorf("query the history: %w", err)
+	}
+	view.New(arg.Stdout).ShowHistory(historyTitle(inputRun.Repo, filter), view.AggregateSamples(samples, bucket))
+	return nil
+}
+
+func historyTitle(repo string, filter *history.Filter) string {
+	names := []string{repo}
+	for _, name := range []string{filter.JobName, filter.StepName, filter.GroupName} {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, " > ") + " (" + filter.Kind() + ")"
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
 	"github.com/suzuki-shunsuke/ghaperf/pkg/log"
 	"github.com/suzuki-shunsuke/ghaperf/pkg/runner"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/view"
 	"github.com/suzuki-shunsuke/ghaperf/pkg/xdg"
 	"github.com/suzuki-shunsuke/slog-error/slogerr"
 )
@@ -26,7 +27,7 @@ type InputRun struct {
 	RunID                   int64
 	JobID                   int64
 	Threshold               string
-	LogFile                 string
+	LogFiles                []string
 	Args                    []string
 	Help                    bool
 	Init                    bool
@@ -35,6 +36,36 @@ type InputRun struct {
 	WorkflowNumber          int
 	WorkflowName            string
 	Config                  string
+	ActionReport            bool
+	CostReport              bool
+	Addr                    string
+	Format                  string
+	History                 bool
+	HistoryJob              string
+	HistoryStep             string
+	HistoryGroup            string
+	Bucket                  string
+	Since                   string
+	Trend                   string
+	TrendStat               string
+	ChangePoint             bool
+	Attempts                bool
+	JobConclusions          []string
+	RunConclusions          []string
+	ByConclusion            bool
+	Hangs                   bool
+	Comment                 bool
+	PRNumber                int
+	JobSummary              bool
+	Budget                  string
+	Interval                string
+	OlderThan               string
+	MaxSize                 string
+	DryRun                  bool
+	Fix                     bool
+	NoCache                 bool
+	Refresh                 bool
+	Offline                 bool
 }
 
 const (
@@ -46,6 +77,7 @@ const (
 
 type Arg struct {
 	Getenv  func(string) string
+	Stdin   io.Reader
 	Stdout  io.Writer
 	Stderr  io.Writer
 	Fs      afero.Fs
@@ -59,6 +91,10 @@ func (c *Controller) Run(ctx context.Context, logger *slog.Logger, logLevelVar *
 		return err
 	}
 
+	if len(inputRun.Args) > 0 {
+		return c.runCommand(ctx, logger, inputRun, arg)
+	}
+
 	if inputRun.Init {
 		if err := config.Init(arg.Fs, "ghaperf.yaml"); err != nil {
 			return fmt.Errorf("initialize config file: %w", err)
@@ -66,46 +102,201 @@ func (c *Controller) Run(ctx context.Context, logger *slog.Logger, logLevelVar *
 		return nil
 	}
 
+	if inputRun.JobSummary {
+		if inputRun.Format != "" && inputRun.Format != "markdown" {
+			return errors.New("--job-summary can't be used with --format except for markdown")
+		}
+		if err := setActionsDefaults(inputRun, arg.Getenv); err != nil {
+			return err
+		}
+	}
+
 	input, err := c.getInput(inputRun, arg)
 	if err != nil {
 		return err
 	}
+	if inputRun.NoCache {
+		removeCache, err := useTempCache(arg.Fs, input)
+		if err != nil {
+			return err
+		}
+		defer removeCache()
+	}
 
 	rArgs := &runner.Args{
-		Stdout: arg.Stdout,
-		Fs:     arg.Fs,
+		Stdin:      arg.Stdin,
+		Stdout:     arg.Stdout,
+		Fs:         arg.Fs,
+		Format:     inputRun.Format,
+		Comment:    inputRun.Comment,
+		JobSummary: inputRun.JobSummary,
 	}
 
-	if inputRun.LogFile != "" {
-		if err := runner.NewRunner(nil, rArgs).RunWithLogFile(input); err != nil {
+	if len(inputRun.LogFiles) != 0 {
+		if err := runner.NewRunner(nil, rArgs).RunWithLogFile(logger, input); err != nil {
 			return fmt.Errorf("run with log file: %w", err)
 		}
 		return nil
 	}
 
+	gh, err := newGitHub(ctx, logger, inputRun, arg)
+	if err != nil {
+		return err
+	}
+	if err := runner.NewRunner(gh, rArgs).Run(ctx, logger, input); err != nil {
+		return err //nolint:wrapcheck
+	}
+	return nil
+}
+
+// newGitHub returns a GitHub client.
+// In offline mode, the client never calls GitHub API and data is read only from the cache.
+func newGitHub(ctx context.Context, logger *slog.Logger, inputRun *InputRun, arg *Arg) (runner.GitHub, error) {
+	if inputRun.Offline {
+		return &github.Offline{}, nil
+	}
 	gh, err := github.New(ctx, logger, &github.InputNew{
 		AccessToken: getGitHubToken(arg.Getenv),
 	})
 	if err != nil {
-		return fmt.Errorf("create GitHub client: %w", err)
+		return nil, fmt.Errorf("create GitHub client: %w", err)
 	}
-	if err := runner.NewRunner(gh, rArgs).Run(ctx, logger, input); err != nil {
-		return err //nolint:wrapcheck
+	return gh, nil
+}
+
+var errUnknownCommand = errors.New("unknown command")
+
+func (c *Controller) runCommand(ctx context.Context, logger *slog.Logger, inputRun *InputRun, arg *Arg) error {
+	switch inputRun.Args[0] {
+	case "serve":
+		return c.serve(ctx, logger, inputRun, arg)
+	case "history":
+		return c.history(inputRun, arg)
+	case "watch":
+		return c.watch(ctx, logger, inputRun, arg)
+	case "cache":
+		return c.cache(inputRun, arg)
+	default:
+		return slogerr.With(errUnknownCommand, "command", inputRun.Args[0]) //nolint:wrapcheck
+	}
+}
+
+var errInvalidFormat = errors.New("--format must be one of markdown, html, csv, and tsv")
+
+func validateFormat(format string) error {
+	switch format {
+	case "", "markdown", runner.FormatHTML, runner.FormatCSV, runner.FormatTSV:
+		return nil
+	default:
+		return slogerr.With(errInvalidFormat, "format", format) //nolint:wrapcheck
+	}
+}
+
+var (
+	errInvalidTrend     = errors.New("--trend must be either day or week")
+	errInvalidTrendStat = errors.New("--trend-stat must be either avg or p90")
+)
+
+func validateTrend(trend, stat string) (string, error) {
+	switch trend {
+	case "", view.BucketDay, view.BucketWeek:
+	default:
+		return "", slogerr.With(errInvalidTrend, "trend", trend) //nolint:wrapcheck
+	}
+	switch stat {
+	case "":
+		return view.TrendStatAvg, nil
+	case view.TrendStatAvg, view.TrendStatP90:
+		return stat, nil
+	default:
+		return "", slogerr.With(errInvalidTrendStat, "trend_stat", stat) //nolint:wrapcheck
+	}
+}
+
+func validateComment(input *InputRun) error {
+	if !input.Comment {
+		if input.PRNumber != 0 {
+			return errors.New("--pr requires --comment")
+		}
+		return nil
+	}
+	if len(input.LogFiles) != 0 {
+		return errors.New("--comment can't be used with --log-file")
+	}
+	if input.Format != "" && input.Format != "markdown" {
+		return errors.New("--comment can't be used with --format except for markdown")
+	}
+	return nil
+}
+
+func validateOffline(input *InputRun) error {
+	if !input.Offline {
+		return nil
+	}
+	switch {
+	case input.NoCache:
+		return errors.New("--offline can't be used with --no-cache")
+	case input.Refresh:
+		return errors.New("--offline can't be used with --refresh")
+	case input.Comment:
+		return errors.New("--offline can't be used with --comment")
 	}
 	return nil
 }
 
 func (c *Controller) getInput(input *InputRun, arg *Arg) (*collector.Input, error) {
+	if err := validateFormat(input.Format); err != nil {
+		return nil, err
+	}
+	trendStat, err := validateTrend(input.Trend, input.TrendStat)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateComment(input); err != nil {
+		return nil, err
+	}
+	if input.NoCache && input.Refresh {
+		return nil, errors.New("--no-cache and --refresh can't be used together")
+	}
+	if err := validateOffline(input); err != nil {
+		return nil, err
+	}
 	threshold, err := getThreshold(input.Threshold, arg.Getenv)
 	if err != nil {
 		return nil, err
 	}
 
-	if input.LogFile != "" {
+	var budget time.Duration
+	if input.Budget != "" {
+		budget, err = time.ParseDuration(input.Budget)
+		if err != nil {
+			return nil, fmt.Errorf("parse --budget. See https://pkg.go.dev/time#ParseDuration: %w", err)
+		}
+	}
+
+	var outputFile, stepSummaryFile string
+	if input.JobSummary {
+		outputFile = arg.Getenv(envGitHubOutput)
+		stepSummaryFile = arg.Getenv(envGitHubStepSummary)
+		if stepSummaryFile == "" {
+			return nil, errStepSummaryNotFound
+		}
+	}
+
+	if len(input.LogFiles) != 0 {
+		cfg := &config.Config{}
+		if err := readConfig(arg.Fs, input.Config, cfg); err != nil {
+			return nil, err
+		}
 		return &collector.Input{
-			Threshold: threshold,
-			LogFile:   input.LogFile,
-			Version:   arg.Version,
+			Threshold:       threshold,
+			LogFiles:        input.LogFiles,
+			Version:         arg.Version,
+			Config:          cfg,
+			Hangs:           input.Hangs,
+			OutputFile:      outputFile,
+			StepSummaryFile: stepSummaryFile,
+			Budget:          budget,
 		}, nil
 	}
 
@@ -117,6 +308,15 @@ func (c *Controller) getInput(input *InputRun, arg *Arg) (*collector.Input, erro
 		return nil, errors.New("without --log-file, repository must be specified")
 	}
 
+	if err := validateCount(input.WorkflowNumber); err != nil {
+		return nil, err
+	}
+
+	if input.ChangePoint && (input.WorkflowName == "" || input.ListWorkflowRunsOptions.Branch == "") {
+		// Workflow runs on different branches aren't comparable
+		return nil, errors.New("--change-point requires --workflow and --workflow-branch")
+	}
+
 	repoOwner, repoName, err := validateRepo(input.Repo)
 	if err != nil {
 		return nil, err
@@ -126,10 +326,24 @@ func (c *Controller) getInput(input *InputRun, arg *Arg) (*collector.Input, erro
 	if err := readConfig(arg.Fs, input.Config, cfg); err != nil {
 		return nil, err
 	}
+	if err := setConclusions(input, cfg); err != nil {
+		return nil, err
+	}
+	if err := setRunStatus(input.ListWorkflowRunsOptions, cfg); err != nil {
+		return nil, err
+	}
+
+	var historyDir string
+	if input.History {
+		historyDir = xdg.DataDir(arg.Getenv, arg.Home)
+	}
 
 	return &collector.Input{
 		Threshold:               threshold,
 		CacheDir:                xdg.CacheDir(arg.Getenv, arg.Home),
+		Refresh:                 input.Refresh,
+		Offline:                 input.Offline,
+		HistoryDir:              historyDir,
 		RepoOwner:               repoOwner,
 		RepoName:                repoName,
 		RunID:                   input.RunID,
@@ -140,11 +354,58 @@ func (c *Controller) getInput(input *InputRun, arg *Arg) (*collector.Input, erro
 		ListWorkflowRunsOptions: input.ListWorkflowRunsOptions,
 		Config:                  cfg,
 		Version:                 arg.Version,
+		ActionReport:            input.ActionReport,
+		CostReport:              input.CostReport,
+		Trend:                   input.Trend,
+		TrendStat:               trendStat,
+		ChangePoint:             input.ChangePoint,
+		Attempts:                input.Attempts,
+		ByConclusion:            input.ByConclusion,
+		Hangs:                   input.Hangs,
+		Comment:                 input.Comment,
+		PRNumber:                input.PRNumber,
+		OutputFile:              outputFile,
+		StepSummaryFile:         stepSummaryFile,
+		Budget:                  budget,
 	}, nil
 }
 
 const defaultThreshold = 30 * time.Second
 
+// setConclusions overrides conclusions in the configuration file with command line options.
+func setConclusions(input *InputRun, cfg *config.Config) error {
+	if len(input.JobConclusions) > 0 {
+		if err := config.ValidateConclusions(input.JobConclusions); err != nil {
+			return fmt.Errorf("validate --job-conclusion: %w", err)
+		}
+		cfg.JobConclusions = input.JobConclusions
+	}
+	if len(input.RunConclusions) > 0 {
+		if err := config.ValidateConclusions(input.RunConclusions); err != nil {
+			return fmt.Errorf("validate --run-conclusion: %w", err)
+		}
+		cfg.RunConclusions = input.RunConclusions
+	}
+	return nil
+}
+
+var errRunStatusConflict = errors.New("--workflow-status can't be used with a different workflow run conclusion")
+
+// setRunStatus filters workflow runs by the conclusion with the GitHub API if only one conclusion is given.
+// Otherwise workflow runs are filtered after --count workflow runs are fetched, so fewer workflow runs may be analyzed.
+// The status parameter of the API accepts only one status or conclusion, so a different --workflow-status is rejected.
+func setRunStatus(opts *github.ListWorkflowRunsOptions, cfg *config.Config) error {
+	if opts == nil || len(cfg.RunConclusions) != 1 {
+		return nil
+	}
+	conclusion := cfg.RunConclusions[0]
+	if opts.Status != "" && opts.Status != conclusion {
+		return slogerr.With(errRunStatusConflict, "workflow_status", opts.Status, "run_conclusion", conclusion) //nolint:wrapcheck
+	}
+	opts.Status = conclusion
+	return nil
+}
+
 func readConfig(fs afero.Fs, path string, cfg *config.Config) error {
 	if path == "" {
 		return nil
@@ -181,6 +442,15 @@ func getGitHubToken(getEnv func(string) string) string {
 	return getEnv(envGitHubToken)
 }
 
+var errInvalidCount = errors.New("--count must be greater than 0")
+
+func validateCount(count int) error {
+	if count < 1 {
+		return slogerr.With(errInvalidCount, "count", count) //nolint:wrapcheck
+	}
+	return nil
+}
+
 var errInvalidRepoArg = errors.New("invalid repository name format")
 
 func validateRepo(repo string) (string, string, error) {
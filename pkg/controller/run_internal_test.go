@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/config"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+)
+
+func TestSetRunStatus(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		status      string
+		conclusions []string
+		want        string
+		isErr       bool
+	}{
+		{name: "a conclusion", conclusions: []string{"failure"}, want: "failure"},
+		{name: "multiple conclusions", conclusions: []string{"failure", "cancelled"}},
+		{name: "no conclusion"},
+		{name: "only status", status: "completed", want: "completed"},
+		{name: "same status", status: "failure", conclusions: []string{"failure"}, want: "failure"},
+		{name: "status with multiple conclusions", status: "completed", conclusions: []string{"failure", "cancelled"}, want: "completed"},
+		{name: "conflict", status: "completed", conclusions: []string{"failure"}, isErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			opts := &github.ListWorkflowRunsOptions{Status: tt.status}
+			err := setRunStatus(opts, &config.Config{RunConclusions: tt.conclusions})
+			if tt.isErr {
+				if err == nil {
+					t.Fatal("an error should be returned")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if opts.Status != tt.want {
+				t.Errorf("status = %q, want %q", opts.Status, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateCount(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		count int
+		isErr bool
+	}{
+		{name: "valid", count: 1},
+		{name: "zero", count: 0, isErr: true},
+		{name: "negative", count: -1, isErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := validateCount(tt.count); (err != nil) != tt.isErr {
+				t.Errorf("validateCount(%d) = %v, want error: %v", tt.count, err, tt.isErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/config"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/server"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/xdg"
+)
+
+const defaultAddr = "127.0.0.1:8080"
+
+func (c *Controller) serve(ctx context.Context, logger *slog.Logger, inputRun *InputRun, arg *Arg) error {
+	cfg := &config.Config{}
+	if err := readConfig(arg.Fs, inputRun.Config, cfg); err != nil {
+		return err
+	}
+	addr := inputRun.Addr
+	if addr == "" {
+		addr = defaultAddr
+	}
+	// The server reads data only from the cache, so GitHub API isn't needed.
+	srv, err := server.New(logger, collector.New(arg.Fs, nil), &collector.Input{
+		CacheDir: xdg.CacheDir(arg.Getenv, arg.Home),
+		Config:   cfg,
+		Version:  arg.Version,
+	})
+	if err != nil {
+		return fmt.Errorf("create a server: %w", err)
+	}
+	if err := srv.ListenAndServe(ctx, addr); err != nil {
+		return fmt.Errorf("run a server: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+const (
+	envGitHubStepSummary = "GITHUB_STEP_SUMMARY"
+	envGitHubOutput      = "GITHUB_OUTPUT"
+	envGitHubRepository  = "GITHUB_REPOSITORY"
+	envGitHubRunID       = "GITHUB_RUN_ID"
+	envGitHubRunAttempt  = "GITHUB_RUN_ATTEMPT"
+)
+
+var errStepSummaryNotFound = errors.New("GITHUB_STEP_SUMMARY isn't set. --job-summary is available only in GitHub Actions")
+
+// setActionsDefaults analyzes the current workflow run in GitHub Actions if no target is specified.
+func setActionsDefaults(input *InputRun, getEnv func(string) string) error {
+	if input.Repo == "" {
+		input.Repo = getEnv(envGitHubRepository)
+	}
+	if input.RunID != 0 || input.JobID != 0 || input.WorkflowName != "" || len(input.LogFiles) != 0 {
+		return nil
+	}
+	if s := getEnv(envGitHubRunID); s != "" {
+		runID, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return fmt.Errorf("parse %s as an integer: %w", envGitHubRunID, err)
+		}
+		input.RunID = runID
+	}
+	if s := getEnv(envGitHubRunAttempt); s != "" && input.AttemptNumber == 0 {
+		attempt, err := strconv.Atoi(s)
+		if err != nil {
+			return fmt.Errorf("parse %s as an integer: %w", envGitHubRunAttempt, err)
+		}
+		input.AttemptNumber = attempt
+	}
+	return nil
+}
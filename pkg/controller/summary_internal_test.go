@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSetActionsDefaults(t *testing.T) {
+	t.Parallel()
+	env := map[string]string{
+		envGitHubRepository: "suzuki-shunsuke/ghaperf",
+		envGitHubRunID:      "100",
+		envGitHubRunAttempt: "2",
+	}
+	tests := []struct {
+		name  string
+		input *InputRun
+		env   map[string]string
+		want  *InputRun
+		isErr bool
+	}{
+		{
+			name:  "current workflow run",
+			input: &InputRun{},
+			env:   env,
+			want:  &InputRun{Repo: "suzuki-shunsuke/ghaperf", RunID: 100, AttemptNumber: 2},
+		},
+		{
+			name:  "attempt is given",
+			input: &InputRun{AttemptNumber: 1},
+			env:   env,
+			want:  &InputRun{Repo: "suzuki-shunsuke/ghaperf", RunID: 100, AttemptNumber: 1},
+		},
+		{
+			name:  "target is given",
+			input: &InputRun{Repo: "suzuki-shunsuke/tfcmt", WorkflowName: "test.yaml"},
+			env:   env,
+			want:  &InputRun{Repo: "suzuki-shunsuke/tfcmt", WorkflowName: "test.yaml"},
+		},
+		{
+			name:  "job id is given",
+			input: &InputRun{JobID: 10},
+			env:   env,
+			want:  &InputRun{Repo: "suzuki-shunsuke/ghaperf", JobID: 10},
+		},
+		{
+			name:  "outside GitHub Actions",
+			input: &InputRun{},
+			env:   map[string]string{},
+			want:  &InputRun{},
+		},
+		{
+			name:  "invalid run id",
+			input: &InputRun{},
+			env:   map[string]string{envGitHubRunID: "foo"},
+			isErr: true,
+		},
+		{
+			name:  "invalid run attempt",
+			input: &InputRun{},
+			env:   map[string]string{envGitHubRunID: "100", envGitHubRunAttempt: "foo"},
+			isErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := setActionsDefaults(tt.input, func(k string) string { return tt.env[k] })
+			if tt.isErr {
+				if err == nil {
+					t.Fatal("an error should be returned")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, tt.input); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
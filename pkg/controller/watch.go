@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/runner"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/watch"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+const defaultInterval = 10 * time.Second
+
+var errInvalidInterval = errors.New("--interval must be greater than 0")
+
+func getInterval(s string) (time.Duration, error) {
+	if s == "" {
+		return defaultInterval, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("parse --interval. See https://pkg.go.dev/time#ParseDuration: %w", err)
+	}
+	if d <= 0 {
+		return 0, slogerr.With(errInvalidInterval, "interval", s) //nolint:wrapcheck
+	}
+	return d, nil
+}
+
+func (c *Controller) watch(ctx context.Context, logger *slog.Logger, inputRun *InputRun, arg *Arg) error {
+	if inputRun.RunID == 0 {
+		return errors.New("--run-id must be specified")
+	}
+	if inputRun.Offline {
+		return errors.New("ghaperf watch can't be used with --offline")
+	}
+	interval, err := getInterval(inputRun.Interval)
+	if err != nil {
+		return err
+	}
+	input, err := c.getInput(inputRun, arg)
+	if err != nil {
+		return err
+	}
+	if inputRun.NoCache {
+		logger.Warn("the cache isn't used with --no-cache, so jobs and steps aren't compared with p90 of cached workflow runs")
+		removeCache, err := useTempCache(arg.Fs, input)
+		if err != nil {
+			return err
+		}
+		defer removeCache()
+	}
+	gh, err := github.New(ctx, logger, &github.InputNew{
+		AccessToken: getGitHubToken(arg.Getenv),
+	})
+	if err != nil {
+		return fmt.Errorf("create GitHub client: %w", err)
+	}
+	// Cached workflow runs are used only to compare durations with p90, so GitHub API isn't needed.
+	if err := watch.New(gh, collector.New(arg.Fs, nil), arg.Stdout, interval).Watch(ctx, logger, input); err != nil {
+		return fmt.Errorf("watch a workflow run: %w", err)
+	}
+	fmt.Fprintln(arg.Stdout)
+	// show the final report
+	if err := runner.NewRunner(gh, &runner.Args{
+		Stdout: arg.Stdout,
+		Fs:     arg.Fs,
+		Format: inputRun.Format,
+	}).Run(ctx, logger, input); err != nil {
+		return err //nolint:wrapcheck
+	}
+	return nil
+}
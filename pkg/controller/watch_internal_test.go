@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetInterval(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		interval string
+		want     time.Duration
+		isErr    bool
+	}{
+		{name: "default", want: defaultInterval},
+		{name: "given", interval: "30s", want: 30 * time.Second},
+		{name: "zero", interval: "0s", isErr: true},
+		{name: "negative", interval: "-1s", isErr: true},
+		{name: "invalid", interval: "foo", isErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			d, err := getInterval(tt.interval)
+			if tt.isErr {
+				if err == nil {
+					t.Fatal("an error should be returned")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d != tt.want {
+				t.Errorf("getInterval() = %s, want %s", d, tt.want)
+			}
+		})
+	}
+}
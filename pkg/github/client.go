@@ -14,12 +14,16 @@ import (
 
 type Client struct {
 	actions ActionsService
+	issues  IssuesService
+	pulls   PullRequestsService
 	http    *http.Client
 }
 
 type InputNew struct {
 	GHTKNEnabled bool
 	AccessToken  string
+	// BaseURL is the base URL of GitHub API (e.g. a local fake server for testing)
+	BaseURL string
 }
 
 type (
@@ -33,6 +37,10 @@ type (
 	WorkflowRunAttemptOptions = github.WorkflowRunAttemptOptions
 	ListWorkflowRunsOptions   = github.ListWorkflowRunsOptions
 	WorkflowRuns              = github.WorkflowRuns
+	IssueComment              = github.IssueComment
+	IssueListCommentsOptions  = github.IssueListCommentsOptions
+	Timestamp                 = github.Timestamp
+	PullRequest               = github.PullRequest
 )
 
 func New(ctx context.Context, logger *slog.Logger, input *InputNew) (*Client, error) {
@@ -40,12 +48,18 @@ func New(ctx context.Context, logger *slog.Logger, input *InputNew) (*Client, er
 	if err != nil {
 		return nil, err
 	}
-	gh, err := github.NewClient(github.WithHTTPClient(httpClient))
+	opts := []github.ClientOptionsFunc{github.WithHTTPClient(httpClient)}
+	if input.BaseURL != "" {
+		opts = append(opts, github.WithURLs(&input.BaseURL, nil))
+	}
+	gh, err := github.NewClient(opts...)
 	if err != nil {
 		return nil, fmt.Errorf("create a GitHub client: %w", err)
 	}
 	return &Client{
 		actions: gh.Actions,
+		issues:  gh.Issues,
+		pulls:   gh.PullRequests,
 		// This is used to download logs with redirect URLs.
 		// The authentication fails if httpClient is used, so http.DefaultClient is used.
 		// > 401 InvalidAuthenticationInfo - Server failed to authenticate the request. Please refer to the information in the www-authenticate header.
@@ -2,11 +2,11 @@ package github
 
 import (
 	"archive/zip"
-	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -25,7 +25,24 @@ func (c *Client) download(ctx context.Context, link string) (*http.Response, err
 	return resp, nil
 }
 
-func (c *Client) GetWorkflowRunLogs(ctx context.Context, owner, repo string, runID int64, attempt int) ([]*zip.File, error) {
+// RunLogs is the log archive of a workflow run.
+// The archive is downloaded to a temporary file and read from the disk, so it isn't loaded on memory.
+// Close must be called to remove the temporary file.
+type RunLogs struct {
+	Files []*zip.File
+	zr    *zip.ReadCloser
+	path  string
+}
+
+func (l *RunLogs) Close() error {
+	l.zr.Close()
+	if err := os.Remove(l.path); err != nil {
+		return fmt.Errorf("remove a temporary file: %w", slogerr.With(err, "path", l.path))
+	}
+	return nil
+}
+
+func (c *Client) GetWorkflowRunLogs(ctx context.Context, owner, repo string, runID int64, attempt int) (*RunLogs, error) {
 	link, res, err := c.actions.GetWorkflowRunAttemptLogs(ctx, owner, repo, runID, attempt, maxRedirects)
 	if err != nil {
 		if res == nil {
@@ -40,27 +57,48 @@ func (c *Client) GetWorkflowRunLogs(ctx context.Context, owner, repo string, run
 	if err != nil {
 		return nil, fmt.Errorf("download workflow run logs: %w", err)
 	}
+	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		defer resp.Body.Close()
 		b, err := io.ReadAll(resp.Body)
 		if err != nil {
 			return nil, fmt.Errorf("read error response body: %w", slogerr.With(err, "status_code", resp.StatusCode))
 		}
 		return nil, fmt.Errorf("download workflow run logs: %w", slogerr.With(errInvalidStatusCode, "status_code", resp.StatusCode, "response_body", string(b)))
 	}
-	return readZip(resp.Body)
+	path, err := downloadToTempFile(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := readZip(path)
+	if err != nil {
+		os.Remove(path)
+		return nil, err
+	}
+	return logs, nil
 }
 
-func readZip(body io.ReadCloser) ([]*zip.File, error) {
-	buf, err := io.ReadAll(body)
+// downloadToTempFile writes the response body to a temporary file and returns the path.
+func downloadToTempFile(body io.Reader) (string, error) {
+	f, err := os.CreateTemp("", "ghaperf-run-logs-*.zip")
 	if err != nil {
-		return nil, fmt.Errorf("read response body: %w", err)
+		return "", fmt.Errorf("create a temporary file: %w", err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, body); err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("download workflow run logs: %w", err)
 	}
-	body.Close()
-	readerAt := bytes.NewReader(buf)
-	zr, err := zip.NewReader(readerAt, int64(len(buf)))
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("close a temporary file: %w", err)
+	}
+	return f.Name(), nil
+}
+
+func readZip(path string) (*RunLogs, error) {
+	zr, err := zip.OpenReader(path)
 	if err != nil {
-		return nil, fmt.Errorf("create a zip reader: %w", err)
+		return nil, fmt.Errorf("open a zip file: %w", slogerr.With(err, "path", path))
 	}
 	files := make([]*zip.File, 0, len(zr.File))
 	for _, f := range zr.File {
@@ -72,5 +110,9 @@ func readZip(body io.ReadCloser) ([]*zip.File, error) {
 		}
 		files = append(files, f)
 	}
-	return files, nil
+	return &RunLogs{
+		Files: files,
+		zr:    zr,
+		path:  path,
+	}, nil
 }
@@ -0,0 +1,53 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v90/github"
+)
+
+type IssuesService interface {
+	ListComments(ctx context.Context, owner, repo string, number int, opts *IssueListCommentsOptions) ([]*IssueComment, *Response, error)
+	CreateComment(ctx context.Context, owner, repo string, number int, comment *IssueComment) (*IssueComment, *Response, error)
+	EditComment(ctx context.Context, owner, repo string, commentID int64, comment *IssueComment) (*IssueComment, *Response, error)
+}
+
+func (c *Client) ListIssueComments(ctx context.Context, owner, repo string, number int) ([]*IssueComment, error) {
+	opts := &IssueListCommentsOptions{
+		ListOptions: ListOptions{
+			PerPage: maxPerPage,
+		},
+	}
+	arr := []*IssueComment{}
+	for range 10 { // max 1000 comments
+		comments, resp, err := c.issues.ListComments(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, fmt.Errorf("list issue comments: %w", err)
+		}
+		arr = append(arr, comments...)
+		if resp.NextPage == 0 {
+			return arr, nil
+		}
+		opts.Page = resp.NextPage
+	}
+	return arr, nil
+}
+
+func (c *Client) CreateIssueComment(ctx context.Context, owner, repo string, number int, body string) error {
+	if _, _, err := c.issues.CreateComment(ctx, owner, repo, number, &IssueComment{
+		Body: github.Ptr(body),
+	}); err != nil {
+		return fmt.Errorf("create an issue comment: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) EditIssueComment(ctx context.Context, owner, repo string, commentID int64, body string) error {
+	if _, _, err := c.issues.EditComment(ctx, owner, repo, commentID, &IssueComment{
+		Body: github.Ptr(body),
+	}); err != nil {
+		return fmt.Errorf("edit an issue comment: %w", err)
+	}
+	return nil
+}
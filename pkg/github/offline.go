@@ -0,0 +1,56 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// ErrOffline is returned by Offline because data which isn't cached can't be fetched in offline mode.
+var ErrOffline = errors.New("not cached and the GitHub API isn't available in offline mode")
+
+// Offline is a GitHub client for offline mode.
+// It never calls the GitHub API, so every method returns ErrOffline.
+type Offline struct{}
+
+func (o *Offline) GetWorkflowJobByID(_ context.Context, owner, repo string, jobID int64) (*WorkflowJob, error) {
+	return nil, slogerr.With(ErrOffline, "repo", owner+"/"+repo, "job_id", jobID) //nolint:wrapcheck
+}
+
+func (o *Offline) GetWorkflowJobLogs(_ context.Context, owner, repo string, jobID int64) (io.ReadCloser, error) {
+	return nil, slogerr.With(ErrOffline, "repo", owner+"/"+repo, "job_id", jobID, "data", "job log") //nolint:wrapcheck
+}
+
+func (o *Offline) GetWorkflowRunByID(_ context.Context, owner, repo string, runID int64, attempt int) (*WorkflowRun, error) {
+	return nil, slogerr.With(ErrOffline, "repo", owner+"/"+repo, "run_id", runID, "run_attempt", attempt) //nolint:wrapcheck
+}
+
+func (o *Offline) ListWorkflowJobs(_ context.Context, owner, repo string, runID int64, attempt int) ([]*WorkflowJob, error) {
+	return nil, slogerr.With(ErrOffline, "repo", owner+"/"+repo, "run_id", runID, "run_attempt", attempt, "data", "jobs") //nolint:wrapcheck
+}
+
+func (o *Offline) ListWorkflowRuns(_ context.Context, owner, repo string, fileName string, _ int, _ *ListWorkflowRunsOptions) ([]*WorkflowRun, error) {
+	return nil, slogerr.With(ErrOffline, "repo", owner+"/"+repo, "workflow", fileName) //nolint:wrapcheck
+}
+
+func (o *Offline) GetWorkflowRunLogs(_ context.Context, owner, repo string, runID int64, attempt int) (*RunLogs, error) {
+	return nil, slogerr.With(ErrOffline, "repo", owner+"/"+repo, "run_id", runID, "run_attempt", attempt, "data", "logs") //nolint:wrapcheck
+}
+
+func (o *Offline) ListIssueComments(_ context.Context, owner, repo string, number int) ([]*IssueComment, error) {
+	return nil, slogerr.With(ErrOffline, "repo", owner+"/"+repo, "number", number) //nolint:wrapcheck
+}
+
+func (o *Offline) CreateIssueComment(_ context.Context, owner, repo string, number int, _ string) error {
+	return slogerr.With(ErrOffline, "repo", owner+"/"+repo, "number", number) //nolint:wrapcheck
+}
+
+func (o *Offline) EditIssueComment(_ context.Context, owner, repo string, commentID int64, _ string) error {
+	return slogerr.With(ErrOffline, "repo", owner+"/"+repo, "comment_id", commentID) //nolint:wrapcheck
+}
+
+func (o *Offline) ListPullRequestsWithCommit(_ context.Context, owner, repo, sha string) ([]*PullRequest, error) {
+	return nil, slogerr.With(ErrOffline, "repo", owner+"/"+repo, "sha", sha) //nolint:wrapcheck
+}
@@ -0,0 +1,22 @@
+package github
+
+import (
+	"context"
+	"fmt"
+)
+
+type PullRequestsService interface {
+	ListPullRequestsWithCommit(ctx context.Context, owner, repo, sha string, opts *ListOptions) ([]*PullRequest, *Response, error)
+}
+
+// ListPullRequestsWithCommit returns pull requests associated with the commit.
+// Unlike pull_requests of a workflow run, this works for push events on the default branch.
+func (c *Client) ListPullRequestsWithCommit(ctx context.Context, owner, repo, sha string) ([]*PullRequest, error) {
+	prs, _, err := c.pulls.ListPullRequestsWithCommit(ctx, owner, repo, sha, &ListOptions{
+		PerPage: maxPerPage,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list pull requests associated with a commit: %w", err)
+	}
+	return prs, nil
+}
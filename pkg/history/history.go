@@ -0,0 +1,158 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/cache"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/view"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/xdg"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// Store records samples of analyzed workflow runs to JSON Lines files.
+// Each workflow run attempt has its own file, so a workflow run is never recorded twice.
+type Store struct {
+	fs      afero.Fs
+	dataDir string
+}
+
+func New(fs afero.Fs, dataDir string) *Store {
+	return &Store{
+		fs:      fs,
+		dataDir: dataDir,
+	}
+}
+
+const dirPermission = 0o755
+
+// Record records samples of completed workflow runs.
+// It returns the number of newly recorded workflow runs.
+func (s *Store) Record(repoOwner, repoName string, runs []*collector.WorkflowRun) (int, error) {
+	if err := s.fs.MkdirAll(xdg.HistoryDir(s.dataDir, repoOwner, repoName), dirPermission); err != nil {
+		return 0, fmt.Errorf("create a history directory: %w", err)
+	}
+	count := 0
+	for _, run := range runs {
+		if run.Run.GetStatus() != "completed" || run.LogHasGone {
+			continue
+		}
+		path := xdg.HistoryFile(s.dataDir, repoOwner, repoName, run.Run.GetID(), run.Run.GetRunAttempt())
+		if f, err := afero.Exists(s.fs, path); err == nil && f {
+			continue
+		}
+		if err := s.record(path, view.ListSamples(run)); err != nil {
+			return count, fmt.Errorf("record samples of a workflow run: %w", slogerr.With(err, "run_id", run.Run.GetID()))
+		}
+		count++
+	}
+	return count, nil
+}
+
+func (s *Store) record(path string, samples []*view.Sample) error {
+	buf := &strings.Builder{}
+	enc := json.NewEncoder(buf)
+	for _, sample := range samples {
+		if err := enc.Encode(sample); err != nil {
+			return fmt.Errorf("encode a sample: %w", err)
+		}
+	}
+	// Write the file atomically because a half-written file would never be rewritten
+	if err := cache.WriteFile(s.fs, path, []byte(buf.String())); err != nil {
+		return fmt.Errorf("write a history file: %w", err)
+	}
+	return nil
+}
+
+type Filter struct {
+	JobName   string
+	StepName  string
+	GroupName string
+	Since     time.Time
+}
+
+// Kind returns the kind of samples to query.
+func (f *Filter) Kind() string {
+	switch {
+	case f.GroupName != "":
+		return view.SampleKindGroup
+	case f.StepName != "":
+		return view.SampleKindStep
+	default:
+		return view.SampleKindJob
+	}
+}
+
+func (f *Filter) match(sample *view.Sample) bool {
+	if sample.Kind != f.Kind() {
+		return false
+	}
+	if !f.Since.IsZero() && sample.StartedAt.Before(f.Since) {
+		return false
+	}
+	if f.JobName != "" && sample.JobName != f.JobName && sample.NormalizedJobName != f.JobName {
+		return false
+	}
+	if f.StepName != "" && sample.StepName != f.StepName {
+		return false
+	}
+	if f.GroupName != "" && sample.GroupName != f.GroupName {
+		return false
+	}
+	return true
+}
+
+// Query returns recorded samples matching the filter.
+func (s *Store) Query(repoOwner, repoName string, filter *Filter) ([]*view.Sample, error) {
+	dir := xdg.HistoryDir(s.dataDir, repoOwner, repoName)
+	infos, err := afero.ReadDir(s.fs, dir)
+	if err != nil {
+		if errors.Is(err, afero.ErrFileNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read a history directory: %w", err)
+	}
+	samples := []*view.Sample{}
+	for _, info := range infos {
+		if info.IsDir() || filepath.Ext(info.Name()) != ".jsonl" {
+			continue
+		}
+		arr, err := s.read(filepath.Join(dir, info.Name()), filter)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, arr...)
+	}
+	return samples, nil
+}
+
+func (s *Store) read(path string, filter *Filter) ([]*view.Sample, error) {
+	f, err := s.fs.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open a history file: %w", slogerr.With(err, "path", path))
+	}
+	defer f.Close()
+	samples := []*view.Sample{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1024*1024) //nolint:mnd
+	for scanner.Scan() {
+		sample := &view.Sample{}
+		if err := json.Unmarshal(scanner.Bytes(), sample); err != nil {
+			return nil, fmt.Errorf("unmarshal a sample: %w", slogerr.With(err, "path", path))
+		}
+		if filter.match(sample) {
+			samples = append(samples, sample)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read a history file: %w", slogerr.With(err, "path", path))
+	}
+	return samples, nil
+}
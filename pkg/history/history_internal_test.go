@@ -0,0 +1,130 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/parser"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/view"
+)
+
+func newRun(id int64, status string, start time.Time) *collector.WorkflowRun {
+	attempt := 1
+	jobName := "test (ubuntu-latest)"
+	stepName := "Run go test"
+	conclusion := "success"
+	ts := func(d time.Duration) *github.Timestamp {
+		return &github.Timestamp{Time: start.Add(d)}
+	}
+	return &collector.WorkflowRun{
+		Run: &github.WorkflowRun{
+			ID:         &id,
+			RunAttempt: &attempt,
+			Status:     &status,
+		},
+		Jobs: []*collector.Job{
+			{
+				Job: &github.WorkflowJob{
+					ID:          &id,
+					Name:        &jobName,
+					Status:      &status,
+					Conclusion:  &conclusion,
+					StartedAt:   ts(0),
+					CompletedAt: ts(time.Minute),
+					Steps: []*github.TaskStep{
+						{Name: &stepName, StartedAt: ts(0), CompletedAt: ts(time.Minute)},
+					},
+				},
+				Groups: []*parser.Group{
+					{Name: "go test", Lines: []*parser.Line{{Timestamp: ts(time.Second).Time}, {Timestamp: ts(50 * time.Second).Time}}},
+				},
+				NormalizedName: "test",
+			},
+		},
+	}
+}
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	store := New(fs, "/data")
+	start := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	logHasGone := newRun(4, "completed", start)
+	logHasGone.LogHasGone = true
+	runs := []*collector.WorkflowRun{
+		newRun(1, "completed", start),
+		newRun(2, "completed", start.Add(24*time.Hour)),
+		newRun(3, "in_progress", start),
+		logHasGone,
+	}
+	count, err := store.Record("suzuki-shunsuke", "ghaperf", runs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 {
+		t.Errorf("the number of recorded runs = %d, want 2", count)
+	}
+	// recorded runs are skipped
+	count, err = store.Record("suzuki-shunsuke", "ghaperf", runs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Errorf("the number of recorded runs = %d, want 0", count)
+	}
+
+	samples, err := store.Query("suzuki-shunsuke", "ghaperf", &Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(samples) == 0 {
+		t.Fatal("no sample is recorded")
+	}
+	if diff := cmp.Diff(view.ListSamples(runs[0])[0], samples[0]); diff != "" {
+		t.Errorf("the recorded sample mismatch (-want +got):\n%s", diff)
+	}
+
+	tests := []struct {
+		name   string
+		filter *Filter
+		count  int
+		kind   string
+	}{
+		{name: "jobs", filter: &Filter{}, count: 2, kind: view.SampleKindJob},
+		{name: "normalized job name", filter: &Filter{JobName: "test"}, count: 2, kind: view.SampleKindJob},
+		{name: "job name", filter: &Filter{JobName: "test (ubuntu-latest)"}, count: 2, kind: view.SampleKindJob},
+		{name: "other job", filter: &Filter{JobName: "lint"}},
+		{name: "steps", filter: &Filter{StepName: "Run go test"}, count: 2, kind: view.SampleKindStep},
+		{name: "groups", filter: &Filter{StepName: "Run go test", GroupName: "go test"}, count: 2, kind: view.SampleKindGroup},
+		{name: "since", filter: &Filter{Since: start.Add(time.Hour)}, count: 1, kind: view.SampleKindJob},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			samples, err := store.Query("suzuki-shunsuke", "ghaperf", tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(samples) != tt.count {
+				t.Fatalf("the number of samples = %d, want %d", len(samples), tt.count)
+			}
+			for _, sample := range samples {
+				if sample.Kind != tt.kind {
+					t.Errorf("the kind of a sample = %s, want %s", sample.Kind, tt.kind)
+				}
+			}
+		})
+	}
+
+	samples, err = store.Query("suzuki-shunsuke", "other", &Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(samples) != 0 {
+		t.Errorf("samples of an unrecorded repository = %v", samples)
+	}
+}
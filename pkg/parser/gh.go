@@ -0,0 +1,38 @@
+package parser
+
+import "strings"
+
+/*
+`gh run view --log` prefixes every line with the job name and the step name separated by tabs.
+
+test	Set up job	2025-10-25T13:48:59.4397988Z Current runner version: '2.329.0'
+test	Run echo "start"	2025-10-25T13:48:59.5432811Z ##[group]Run echo "start"
+*/
+
+type prefix struct {
+	job     string
+	step    string
+	content string
+}
+
+// parsePrefix parses the prefix of `gh run view --log`.
+// It returns false if the line doesn't have the prefix.
+// A line whose content doesn't start with a timestamp isn't regarded as prefixed, so tabs in plain logs don't matter.
+func parsePrefix(txt string) (*prefix, bool) {
+	job, rest, ok := strings.Cut(txt, "\t")
+	if !ok {
+		return nil, false
+	}
+	step, content, ok := strings.Cut(rest, "\t")
+	if !ok {
+		return nil, false
+	}
+	if parseLine(content).Continue {
+		return nil, false
+	}
+	return &prefix{
+		job:     job,
+		step:    step,
+		content: content,
+	}, true
+}
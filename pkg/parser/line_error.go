@@ -0,0 +1,41 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrLineTooLong      = errors.New("the line is too long, so it's truncated")
+	ErrNoTimestamp      = errors.New("the first line of the log doesn't start with a timestamp, so it's skipped")
+	ErrInvalidTimestamp = errors.New("the line starts with an invalid timestamp, so it's regarded as a continuation of the previous line")
+)
+
+// LineError is an error of a line which is skipped or parsed partially.
+// Parsing a log doesn't fail by these errors because the rest of the log is still available.
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// MaxLineErrors is the maximum number of line errors kept in a log.
+const MaxLineErrors = 100
+
+func (l *Log) addError(line int, err error) {
+	l.ErrorCount++
+	if len(l.Errors) >= MaxLineErrors {
+		return
+	}
+	l.Errors = append(l.Errors, &LineError{
+		Line: line,
+		Err:  err,
+	})
+}
@@ -2,6 +2,8 @@ package parser
 
 import (
 	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"regexp"
@@ -52,9 +54,16 @@ import (
 2025-10-25T13:49:01.7764649Z Cleaning up orphan processes
 */
 
+// Group is a log group.
+// Lines keeps only the first HeadLines lines and the last TailLines lines so that memory usage is bounded regardless of the log size.
+// Omitted is the number of lines between them which are dropped.
 type Group struct {
-	Name      string
-	Lines     []*Line
+	Name    string
+	Lines   []*Line
+	Omitted int
+	// Step is the step name in the prefix of `gh run view --log`.
+	// It's empty for the other formats.
+	Step      string
 	duration  time.Duration
 	startTime time.Time
 	endTime   time.Time
@@ -103,12 +112,23 @@ type Line struct {
 	Start     bool
 	Continue  bool
 	JobName   string
+	// Step is the step name in the prefix of `gh run view --log`
+	Step string
 }
 
 type Log struct {
-	JobName  string
-	Groups   []*Group
-	duration time.Duration
+	JobName string
+	Groups  []*Group
+	// Steps are inferred from the log because logs don't have step boundaries, unless the log is prefixed
+	Steps []*Step
+	// Prefixed is true if the log is the output of `gh run view --log`.
+	// Then the job name and steps are read from prefixes of lines.
+	Prefixed bool
+	// Errors are errors of lines which are skipped or parsed partially.
+	// Only the first MaxLineErrors errors are kept and ErrorCount is the number of all errors.
+	Errors     []*LineError
+	ErrorCount int
+	duration   time.Duration
 }
 
 func (l *Log) Duration() time.Duration {
@@ -125,37 +145,190 @@ func (l *Log) Duration() time.Duration {
 	return l.duration
 }
 
+const (
+	HeadLines = 100
+	TailLines = 100
+	// MaxLineLength is the maximum length of a line kept in memory.
+	// The rest of a longer line such as minified JSON or base64 is dropped.
+	MaxLineLength = 64 * 1024
+)
+
+// Parse parses a log incrementally, so the whole log isn't loaded on memory.
+// If the log is the output of `gh run view --log`, prefixes of lines are removed and logs of all jobs are parsed as a log.
+// Use ParseJobs to parse logs of jobs separately.
 func Parse(data io.Reader) (*Log, error) {
-	scanner := bufio.NewScanner(data)
-	log := &Log{}
-	group := &Group{}
+	p := newLogParser()
+	if err := scan(data, func(string) *logParser {
+		return p
+	}); err != nil {
+		return nil, err
+	}
+	return p.finish(), nil
+}
 
-	for scanner.Scan() {
-		txt := scanner.Text()
-		line := parseLine(txt)
-		if log.JobName == "" && line.JobName != "" {
-			log.JobName = line.JobName
+// ParseJobs parses a log which may include logs of multiple jobs like the output of `gh run view --log`.
+// Logs are returned in order of their first lines.
+// A log of the other format is parsed as a log of a job.
+func ParseJobs(data io.Reader) ([]*Log, error) {
+	parsers := []*logParser{}
+	m := map[string]*logParser{}
+	if err := scan(data, func(job string) *logParser {
+		p, ok := m[job]
+		if !ok {
+			p = newLogParser()
+			m[job] = p
+			parsers = append(parsers, p)
 		}
+		return p
+	}); err != nil {
+		return nil, err
+	}
+	logs := make([]*Log, len(parsers))
+	for i, p := range parsers {
+		logs[i] = p.finish()
+	}
+	if len(logs) == 0 {
+		logs = append(logs, newLogParser().finish())
+	}
+	return logs, nil
+}
+
+// scan reads lines and passes them to the parser of the job.
+// Lines without the prefix of `gh run view --log` belong to the job of the previous line.
+func scan(data io.Reader, getParser func(job string) *logParser) error {
+	br := bufio.NewReader(data)
+	job := ""
+	for n := 1; ; n++ {
+		txt, truncated, err := readLine(br)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("read a log file: %w", err)
+		}
+		line := parseLine(txt)
 		if line.Continue {
-			group.Lines[len(group.Lines)-1].Content += "\n" + line.Content
+			if prefix, ok := parsePrefix(line.Content); ok {
+				job = prefix.job
+				line = parseLine(prefix.content)
+				line.Step = prefix.step
+				getParser(job).prefixed(prefix.job)
+			}
+		}
+		p := getParser(job)
+		if truncated {
+			p.log.addError(n, ErrLineTooLong)
+		}
+		p.add(n, line)
+	}
+}
+
+// readLine reads a line of arbitrary length.
+// Only the first MaxLineLength bytes are kept and true is returned if the line is truncated.
+// io.EOF is returned only if no line is left.
+func readLine(br *bufio.Reader) (string, bool, error) {
+	var buf []byte
+	truncated := false
+	for {
+		b, err := br.ReadSlice('\n')
+		b = bytes.TrimSuffix(b, []byte("\n"))
+		n := min(len(b), MaxLineLength-len(buf))
+		buf = append(buf, b[:n]...)
+		truncated = truncated || n < len(b)
+		if errors.Is(err, bufio.ErrBufferFull) {
 			continue
 		}
-		group.Lines = append(group.Lines, line)
-		if line.Start {
-			// End the previous group
-			log.Groups = append(log.Groups, group)
-			group = &Group{
-				Name: line.Content,
-			}
+		if err != nil && (!errors.Is(err, io.EOF) || (len(buf) == 0 && !truncated)) {
+			return "", false, err //nolint:wrapcheck
 		}
+		return string(bytes.TrimSuffix(buf, []byte("\r"))), truncated, nil
 	}
-	log.Groups = append(log.Groups, group)
+}
+
+// logParser parses a log of a job line by line.
+type logParser struct {
+	log   *Log
+	group *Group
+}
 
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("scan a log file: %w", err)
+func newLogParser() *logParser {
+	return &logParser{
+		log:   &Log{},
+		group: &Group{},
 	}
+}
 
-	return log, nil
+// prefixed marks the log as the output of `gh run view --log`.
+// The job name in the prefix is used because it's the name shown in GitHub.
+func (p *logParser) prefixed(job string) {
+	p.log.Prefixed = true
+	p.log.JobName = job
+}
+
+func (p *logParser) add(n int, line *Line) {
+	if p.log.JobName == "" && line.JobName != "" {
+		p.log.JobName = line.JobName
+	}
+	if line.Continue {
+		p.appendContinuation(n, line)
+		return
+	}
+	p.log.trackStep(line)
+	if p.group.Step == "" {
+		p.group.Step = line.Step
+	}
+	p.group.Lines = append(p.group.Lines, line)
+	if len(p.group.Lines) >= HeadLines+2*TailLines {
+		p.group.compact()
+	}
+	if line.Start {
+		// End the previous group
+		p.log.Groups = append(p.log.Groups, p.group)
+		p.group = &Group{
+			Name: line.Content,
+			Step: line.Step,
+		}
+	}
+}
+
+// appendContinuation appends a line without a timestamp to the previous line.
+// The start line of a group belongs to the previous group, so the previous line may be in the previous group.
+func (p *logParser) appendContinuation(n int, line *Line) {
+	if err := timestampError(line.Content); err != nil {
+		p.log.addError(n, err)
+	}
+	group := p.group
+	if len(group.Lines) == 0 && len(p.log.Groups) != 0 {
+		group = p.log.Groups[len(p.log.Groups)-1]
+	}
+	if len(group.Lines) == 0 {
+		p.log.addError(n, ErrNoTimestamp)
+		return
+	}
+	prev := group.Lines[len(group.Lines)-1]
+	if len(prev.Content) >= MaxLineLength {
+		// bound the memory usage of a long multi-line output
+		return
+	}
+	prev.Content += "\n" + line.Content
+}
+
+func (p *logParser) finish() *Log {
+	p.log.Groups = append(p.log.Groups, p.group)
+	return p.log
+}
+
+// compact drops lines between the first HeadLines lines and the last TailLines lines.
+// The first and last lines are kept so that the start time and the end time of the group don't change.
+func (g *Group) compact() {
+	n := len(g.Lines) - HeadLines - TailLines
+	if n <= 0 {
+		return
+	}
+	copy(g.Lines[HeadLines:], g.Lines[len(g.Lines)-TailLines:])
+	clear(g.Lines[HeadLines+TailLines:])
+	g.Lines = g.Lines[:HeadLines+TailLines]
+	g.Omitted += n
 }
 
 var ansiEscapeSequence = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
@@ -173,7 +346,7 @@ func parseLine(txt string) *Line {
 		}
 	}
 	// 2025-10-25T13:48:59.4421674Z ##[group]Runner Image Provisioner
-	t, err := time.Parse("2006-01-02T15:04:05.9999999Z", d)
+	t, err := parseTimestamp(d)
 	if err != nil {
 		// The log doesn't start with timestamp.
 		// This is a continuation from the previous log.
@@ -204,3 +377,26 @@ func parseLine(txt string) *Line {
 		}
 	}
 }
+
+// parseTimestamp parses a timestamp at the beginning of a line.
+// GitHub-hosted runners output UTC timestamps with 7 fractional digits, but self-hosted runners and other tools may output RFC3339 timestamps with offsets and other precisions.
+// Timestamps are converted to UTC so that they are comparable with timestamps of the GitHub API.
+func parseTimestamp(s string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, err //nolint:wrapcheck
+	}
+	return t.UTC(), nil
+}
+
+// timestampError returns an error if a line regarded as a continuation starts with something like a timestamp which can't be parsed.
+func timestampError(content string) error {
+	d, _, _ := strings.Cut(content, " ")
+	if len(d) < len("2006-01-02T15:04:05") || d[4] != '-' || d[7] != '-' || d[10] != 'T' {
+		return nil
+	}
+	if _, err := parseTimestamp(d); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidTimestamp, err)
+	}
+	return nil
+}
@@ -1,6 +1,9 @@
 package parser
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -49,6 +52,22 @@ func Test_parseLine(t *testing.T) {
 				Timestamp: time.Date(2025, 10, 29, 13, 56, 22, 727375700, time.UTC),
 			},
 		},
+		{
+			name: "offset",
+			txt:  "2025-10-25T22:48:59.123+09:00 hello",
+			line: &Line{
+				Content:   "hello",
+				Timestamp: time.Date(2025, 10, 25, 13, 48, 59, 123000000, time.UTC),
+			},
+		},
+		{
+			name: "no fractional seconds",
+			txt:  "2025-10-25T13:48:59Z hello",
+			line: &Line{
+				Content:   "hello",
+				Timestamp: time.Date(2025, 10, 25, 13, 48, 59, 0, time.UTC),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -60,3 +79,177 @@ func Test_parseLine(t *testing.T) {
 		})
 	}
 }
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2025, 10, 25, 13, 48, 59, 0, time.UTC)
+	const count = 1000
+	var b strings.Builder
+	b.WriteString(start.Format("2006-01-02T15:04:05.0000000Z") + " ##[group]Run make test\n")
+	for i := range count {
+		fmt.Fprintf(&b, "%s line %d\n", start.Add(time.Duration(i+1)*time.Second).Format("2006-01-02T15:04:05.0000000Z"), i)
+	}
+	log, err := Parse(strings.NewReader(b.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(log.Groups) != 2 {
+		t.Fatalf("the number of groups = %d, want 2", len(log.Groups))
+	}
+	group := log.Groups[1]
+	if len(group.Lines) > HeadLines+2*TailLines {
+		t.Errorf("the number of lines = %d, want <= %d", len(group.Lines), HeadLines+2*TailLines)
+	}
+	if got := len(group.Lines) + group.Omitted; got != count {
+		t.Errorf("the number of lines and omitted lines = %d, want %d", got, count)
+	}
+	if group.Lines[0].Content != "line 0" {
+		t.Errorf("the first line = %s, want line 0", group.Lines[0].Content)
+	}
+	if got := group.Lines[len(group.Lines)-1].Content; got != fmt.Sprintf("line %d", count-1) {
+		t.Errorf("the last line = %s, want line %d", got, count-1)
+	}
+	if d := group.Duration(); d != (count-1)*time.Second {
+		t.Errorf("the duration = %s, want %s", d, (count-1)*time.Second)
+	}
+}
+
+func TestParse_lineErrors(t *testing.T) {
+	t.Parallel()
+	long := strings.Repeat("a", MaxLineLength*3)
+	data := "orphan line\n" +
+		"2025-10-25T13:48:59.0000000Z ##[group]Run make\n" +
+		"continuation of the group start\n" +
+		"2025-10-25T13:49:00.0000000Z " + long + "\r\n" +
+		"2025-13-45T13:49:00.0000000Z invalid timestamp\n" +
+		"2025-10-25T13:49:02.0000000Z ##[endgroup]"
+	log, err := Parse(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantErrs := []error{ErrNoTimestamp, ErrLineTooLong, ErrInvalidTimestamp}
+	wantLines := []int{1, 4, 5}
+	if len(log.Errors) != len(wantErrs) || log.ErrorCount != len(wantErrs) {
+		t.Fatalf("line errors = %v (%d), want %d errors", log.Errors, log.ErrorCount, len(wantErrs))
+	}
+	for i, e := range log.Errors {
+		if !errors.Is(e, wantErrs[i]) || e.Line != wantLines[i] {
+			t.Errorf("line error %d = %v, want line %d: %v", i, e, wantLines[i], wantErrs[i])
+		}
+	}
+	if len(log.Groups) != 2 {
+		t.Fatalf("the number of groups = %d, want 2", len(log.Groups))
+	}
+	if content := log.Groups[0].Lines[0].Content; content != "Run make\ncontinuation of the group start" {
+		t.Errorf("the continuation of the group start = %q", content)
+	}
+	lines := log.Groups[1].Lines
+	if len(lines) != 2 {
+		t.Fatalf("the number of lines = %d, want 2", len(lines))
+	}
+	// the line with the invalid timestamp is appended to the truncated line
+	if want := long[:MaxLineLength-len("2025-10-25T13:49:00.0000000Z ")] + "\n2025-13-45T13:49:00.0000000Z invalid timestamp"; lines[0].Content != want {
+		t.Errorf("the long line isn't truncated: the length = %d, want %d", len(lines[0].Content), len(want))
+	}
+	if d := log.Groups[1].Duration(); d != 2*time.Second {
+		t.Errorf("the duration = %s, want 2s", d)
+	}
+}
+
+func TestParse_steps(t *testing.T) {
+	t.Parallel()
+	data := `2025-10-25T13:48:59.0000000Z Current runner version: '2.329.0'
+2025-10-25T13:48:59.5000000Z ##[group]Runner Image Provisioner
+2025-10-25T13:49:00.0000000Z ##[endgroup]
+2025-10-25T13:49:01.0000000Z ##[group]Run actions/checkout@v4
+2025-10-25T13:49:01.5000000Z ##[endgroup]
+2025-10-25T13:49:04.0000000Z ##[group]Run make test
+2025-10-25T13:49:05.0000000Z ##[endgroup]
+2025-10-25T13:49:30.0000000Z Post job cleanup.
+2025-10-25T13:49:31.0000000Z Cleaning up orphan processes
+`
+	log, err := Parse(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(sec int) time.Time {
+		return time.Date(2025, 10, 25, 13, 48, 0, 0, time.UTC).Add(time.Duration(sec) * time.Second)
+	}
+	want := []*Step{
+		{Name: "Set up job", StartTime: at(59), EndTime: at(61)},
+		{Name: "Run actions/checkout@v4", StartTime: at(61), EndTime: at(64)},
+		{Name: "Run make test", StartTime: at(64), EndTime: at(90)},
+		{Name: "Post job cleanup", StartTime: at(90), EndTime: at(91)},
+		{Name: "Complete job", StartTime: at(91), EndTime: at(91)},
+	}
+	if diff := cmp.Diff(want, log.Steps); diff != "" {
+		t.Errorf("steps mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseJobs(t *testing.T) {
+	t.Parallel()
+	data := "build\tSet up job\t\ufeff2025-10-25T13:48:59.0000000Z Current runner version: '2.329.0'\n" +
+		"build\tRun make build\t2025-10-25T13:49:01.0000000Z ##[group]Run make build\n" +
+		"build\tRun make build\t2025-10-25T13:49:01.5000000Z ##[endgroup]\n" +
+		"build\tComplete job\t2025-10-25T13:49:10.0000000Z Cleaning up orphan processes\n" +
+		"test\tSet up job\t2025-10-25T13:50:00.0000000Z Current runner version: '2.329.0'\n" +
+		"test\tCache\t2025-10-25T13:50:02.0000000Z Cache restored\n" +
+		"continued\n" +
+		"test\tComplete job\t2025-10-25T13:50:05.0000000Z Cleaning up orphan processes\n"
+	logs, err := ParseJobs(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 2 {
+		t.Fatalf("the number of logs = %d, want 2", len(logs))
+	}
+	at := func(sec int) time.Time {
+		return time.Date(2025, 10, 25, 13, 48, 0, 0, time.UTC).Add(time.Duration(sec) * time.Second)
+	}
+	for i, want := range []struct {
+		job   string
+		steps []*Step
+	}{
+		{
+			job: "build",
+			steps: []*Step{
+				{Name: "Set up job", StartTime: at(59), EndTime: at(61)},
+				{Name: "Run make build", StartTime: at(61), EndTime: at(70)},
+				{Name: "Complete job", StartTime: at(70), EndTime: at(70)},
+			},
+		},
+		{
+			job: "test",
+			steps: []*Step{
+				{Name: "Set up job", StartTime: at(120), EndTime: at(122)},
+				// steps without log groups are also available
+				{Name: "Cache", StartTime: at(122), EndTime: at(125)},
+				{Name: "Complete job", StartTime: at(125), EndTime: at(125)},
+			},
+		},
+	} {
+		log := logs[i]
+		if !log.Prefixed || log.JobName != want.job {
+			t.Errorf("log %d: job = %s (prefixed: %v), want %s (prefixed: true)", i, log.JobName, log.Prefixed, want.job)
+		}
+		if diff := cmp.Diff(want.steps, log.Steps); diff != "" {
+			t.Errorf("log %d: steps mismatch (-want +got):\n%s", i, diff)
+		}
+	}
+	if step := logs[0].Groups[1].Step; step != "Run make build" {
+		t.Errorf("the step of the group = %s, want Run make build", step)
+	}
+	if content := logs[1].Groups[0].Lines[1].Content; content != "Cache restored\ncontinued" {
+		t.Errorf("the continuation line isn't joined: %q", content)
+	}
+
+	// plain logs aren't prefixed even if they include tabs
+	logs, err = ParseJobs(strings.NewReader("2025-10-25T13:48:59.0000000Z a\tb\t2025-10-25T13:48:59.0000000Z c\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 1 || logs[0].Prefixed {
+		t.Errorf("a plain log is parsed as %d prefixed logs", len(logs))
+	}
+}
@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"strings"
+	"time"
+)
+
+// Step is a step inferred from a log.
+// Logs don't have step boundaries, so a step is assumed to start at a "Run ..." group, "Post job cleanup.", or "Cleaning up orphan processes",
+// and to end when the next step starts.
+// Steps of composite actions are regarded as separate steps because they also start with "Run ..." groups.
+// Steps of the output of `gh run view --log` are read from prefixes of lines instead.
+type Step struct {
+	Name      string
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+func (s *Step) Duration() time.Duration {
+	return s.EndTime.Sub(s.StartTime)
+}
+
+const (
+	stepSetUpJob    = "Set up job"
+	stepPostJob     = "Post job cleanup"
+	stepCompleteJob = "Complete job"
+)
+
+// stepName returns the name of the step starting at the line.
+// It returns an empty string if the line doesn't start a step.
+func stepName(line *Line) string {
+	switch {
+	case line.Start && strings.HasPrefix(line.Content, "Run "):
+		return line.Content
+	case line.Content == "Post job cleanup.":
+		return stepPostJob
+	case line.Content == "Cleaning up orphan processes":
+		return stepCompleteJob
+	default:
+		return ""
+	}
+}
+
+// trackStep updates inferred steps with the line.
+// Lines before the first step belong to the step "Set up job".
+// If the line has the step name of `gh run view --log`, the step name is used instead of inference.
+func (l *Log) trackStep(line *Line) {
+	name := stepName(line)
+	if line.Step != "" {
+		name = line.Step
+		if len(l.Steps) != 0 && l.Steps[len(l.Steps)-1].Name == name {
+			name = ""
+		}
+	}
+	if len(l.Steps) != 0 {
+		l.Steps[len(l.Steps)-1].EndTime = line.Timestamp
+		if name == "" {
+			return
+		}
+	}
+	if name == "" {
+		name = stepSetUpJob
+	}
+	l.Steps = append(l.Steps, &Step{
+		Name:      name,
+		StartTime: line.Timestamp,
+		EndTime:   line.Timestamp,
+	})
+}
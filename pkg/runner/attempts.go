@@ -0,0 +1,27 @@
+package runner
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// listAttempts returns previous attempts of workflow runs.
+// Workflow runs which fail to get previous attempts are ignored.
+func (r *Runner) listAttempts(ctx context.Context, logger *slog.Logger, input *collector.Input, runs []*collector.WorkflowRun) []*collector.WorkflowRun {
+	var attempts []*collector.WorkflowRun
+	for _, run := range runs {
+		if run.Run.GetRunAttempt() <= 1 {
+			continue
+		}
+		arr, err := r.collector.ListAttempts(ctx, logger, input, run.Run)
+		if err != nil {
+			slogerr.WithError(logger, err).Error("list previous attempts of a workflow run", "run_id", run.Run.GetID())
+			continue
+		}
+		attempts = append(attempts, arr...)
+	}
+	return attempts
+}
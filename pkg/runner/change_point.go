@@ -0,0 +1,39 @@
+package runner
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/view"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// resolvePullRequests sets pull requests associated with head commits of change points.
+// pull_requests of workflow runs are empty for push events on the default branch, so pull requests are resolved from the head commit.
+func (r *Runner) resolvePullRequests(ctx context.Context, logger *slog.Logger, input *collector.Input, cps []*view.ChangePoint) {
+	cache := map[string][]int{}
+	for _, cp := range cps {
+		if len(cp.PullRequests) > 0 {
+			continue
+		}
+		sha := cp.Run.GetHeadSHA()
+		if sha == "" {
+			continue
+		}
+		if numbers, ok := cache[sha]; ok {
+			cp.PullRequests = numbers
+			continue
+		}
+		prs, err := r.gh.ListPullRequestsWithCommit(ctx, input.RepoOwner, input.RepoName, sha)
+		if err != nil {
+			slogerr.WithError(logger, err).Warn("list pull requests associated with the head commit of the change point", "sha", sha)
+		}
+		numbers := make([]int, len(prs))
+		for i, pr := range prs {
+			numbers[i] = pr.GetNumber()
+		}
+		cache[sha] = numbers
+		cp.PullRequests = numbers
+	}
+}
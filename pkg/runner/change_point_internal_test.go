@@ -0,0 +1,66 @@
+package runner
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/view"
+)
+
+// fakeGitHub returns pull requests associated with commits.
+type fakeGitHub struct {
+	github.Offline
+
+	pulls map[string][]int
+	calls int
+}
+
+func (f *fakeGitHub) ListPullRequestsWithCommit(ctx context.Context, owner, repo, sha string) ([]*github.PullRequest, error) {
+	f.calls++
+	numbers, ok := f.pulls[sha]
+	if !ok {
+		return f.Offline.ListPullRequestsWithCommit(ctx, owner, repo, sha)
+	}
+	prs := make([]*github.PullRequest, len(numbers))
+	for i, number := range numbers {
+		prs[i] = &github.PullRequest{Number: &number}
+	}
+	return prs, nil
+}
+
+func TestRunner_resolvePullRequests(t *testing.T) {
+	t.Parallel()
+	newChangePoint := func(sha string, prs ...int) *view.ChangePoint {
+		return &view.ChangePoint{
+			Run:          &github.WorkflowRun{HeadSHA: &sha},
+			PullRequests: prs,
+		}
+	}
+	gh := &fakeGitHub{
+		pulls: map[string][]int{
+			"push": {10},
+		},
+	}
+	cps := []*view.ChangePoint{
+		newChangePoint("pull_request", 5),
+		newChangePoint("push"),
+		newChangePoint("push"),
+		newChangePoint("unknown"),
+	}
+	r := &Runner{gh: gh}
+	r.resolvePullRequests(context.Background(), slog.New(slog.DiscardHandler), &collector.Input{}, cps)
+	got := make([][]int, len(cps))
+	for i, cp := range cps {
+		got[i] = cp.PullRequests
+	}
+	if diff := cmp.Diff([][]int{{5}, {10}, {10}, {}}, got); diff != "" {
+		t.Errorf("pull requests mismatch (-want +got):\n%s", diff)
+	}
+	if gh.calls != 2 {
+		t.Errorf("the GitHub API is called %d times, want 2", gh.calls)
+	}
+}
@@ -0,0 +1,37 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/comment"
+)
+
+var errPullRequestNotFound = errors.New("pull request isn't found. Please specify --pr")
+
+// postComment posts the report to the pull request.
+// The pull request is given by --pr or associated with the analyzed workflow run.
+func (r *Runner) postComment(ctx context.Context, logger *slog.Logger, input *collector.Input) error {
+	number := input.PRNumber
+	if number == 0 {
+		number = r.pullRequest
+	}
+	if number == 0 {
+		return errPullRequestNotFound
+	}
+	if err := comment.New(r.gh).Post(ctx, logger, input.RepoOwner, input.RepoName, number, r.report.String()); err != nil {
+		return fmt.Errorf("post the report to the pull request: %w", err)
+	}
+	return nil
+}
+
+// setPullRequest sets the pull request associated with the workflow run.
+func (r *Runner) setPullRequest(run *collector.WorkflowRun) {
+	if run == nil || run.Run == nil || len(run.Run.PullRequests) == 0 {
+		return
+	}
+	r.pullRequest = run.Run.PullRequests[0].GetNumber()
+}
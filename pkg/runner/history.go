@@ -0,0 +1,23 @@
+package runner
+
+import (
+	"log/slog"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/history"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// recordHistory records samples of workflow runs to the history store if --history is set.
+// Failing to record the history doesn't fail the analysis.
+func (r *Runner) recordHistory(logger *slog.Logger, input *collector.Input, runs []*collector.WorkflowRun) {
+	if input.HistoryDir == "" {
+		return
+	}
+	count, err := history.New(r.fs, input.HistoryDir).Record(input.RepoOwner, input.RepoName, runs)
+	if err != nil {
+		slogerr.WithError(logger, err).Warn("record the history of workflow runs")
+		return
+	}
+	logger.Debug("recorded the history of workflow runs", "count", count)
+}
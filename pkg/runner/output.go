@@ -0,0 +1,68 @@
+package runner
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/view"
+)
+
+const filePermission = 0o644
+
+// outputValueReplacer replaces line breaks because values of step outputs must be single lines.
+var outputValueReplacer = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ") //nolint:gochecknoglobals
+
+// writeOutputs sets step outputs of GitHub Actions.
+// https://docs.github.com/en/actions/reference/workflows-and-actions/workflow-commands#setting-an-output-parameter
+func (r *Runner) writeOutputs(input *collector.Input, summary *view.Summary) error {
+	if input.OutputFile == "" {
+		return nil
+	}
+	outputs := [][2]string{
+		{"total_duration", strconv.Itoa(int(summary.TotalDuration.Seconds()))},
+		{"slowest_step", summary.SlowestStep},
+		{"slowest_step_duration", strconv.Itoa(int(summary.SlowestStepDuration.Seconds()))},
+	}
+	if input.Budget > 0 {
+		outputs = append(outputs, [2]string{"budget_status", summary.BudgetStatus(input.Budget)})
+	}
+	f, err := r.fs.OpenFile(input.OutputFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, filePermission)
+	if err != nil {
+		return fmt.Errorf("open the step output file: %w", err)
+	}
+	defer f.Close()
+	for _, output := range outputs {
+		if _, err := fmt.Fprintf(f, "%s=%s\n", output[0], outputValueReplacer.Replace(output[1])); err != nil {
+			return fmt.Errorf("write a step output: %w", err)
+		}
+	}
+	return nil
+}
+
+// writeStepSummary appends the report to the job summary of GitHub Actions.
+func (r *Runner) writeStepSummary(input *collector.Input, summary *view.Summary) error {
+	if input.StepSummaryFile == "" {
+		return nil
+	}
+	f, err := r.fs.OpenFile(input.StepSummaryFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, filePermission)
+	if err != nil {
+		return fmt.Errorf("open the job summary file: %w", err)
+	}
+	defer f.Close()
+	return summary.ShowStepSummary(f, input.Budget, r.report.String()) //nolint:wrapcheck
+}
+
+// writeActionsReport sets step outputs and writes the job summary of GitHub Actions.
+func (r *Runner) writeActionsReport(input *collector.Input) error {
+	if input.OutputFile == "" && input.StepSummaryFile == "" {
+		return nil
+	}
+	summary := view.Summarize(r.analyzedRuns)
+	if err := r.writeOutputs(input, summary); err != nil {
+		return err
+	}
+	return r.writeStepSummary(input, summary)
+}
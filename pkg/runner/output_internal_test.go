@@ -0,0 +1,128 @@
+package runner
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+)
+
+func newTestRuns() []*collector.WorkflowRun {
+	start := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	str := func(s string) *string { return &s }
+	ts := func(d time.Duration) *github.Timestamp {
+		return &github.Timestamp{Time: start.Add(d)}
+	}
+	return []*collector.WorkflowRun{
+		{
+			Jobs: []*collector.Job{
+				{
+					Job: &github.WorkflowJob{
+						Name:        str("build"),
+						Status:      str("completed"),
+						Conclusion:  str("success"),
+						StartedAt:   ts(0),
+						CompletedAt: ts(5 * time.Minute),
+						Steps: []*github.TaskStep{
+							{Name: str("checkout"), Status: str("completed"), Conclusion: str("success"), StartedAt: ts(0), CompletedAt: ts(time.Minute)},
+							{Name: str("go test\r\n| tee"), Status: str("completed"), Conclusion: str("success"), StartedAt: ts(time.Minute), CompletedAt: ts(4 * time.Minute)},
+						},
+					},
+					NormalizedName: "build",
+				},
+			},
+		},
+	}
+}
+
+func TestRunner_writeActionsReport(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		budget  time.Duration
+		outputs string
+		summary []string
+	}{
+		{
+			name:    "no budget",
+			outputs: "total_duration=300\nslowest_step=build > go test | tee\nslowest_step_duration=180\n",
+			summary: []string{
+				"| Total Duration | Slowest Step |\n| --- | --- |\n| 5m0s | build > go test \\| tee (3m0s) |\n",
+			},
+		},
+		{
+			name:    "within the budget",
+			budget:  10 * time.Minute,
+			outputs: "total_duration=300\nslowest_step=build > go test | tee\nslowest_step_duration=180\nbudget_status=within\n",
+			summary: []string{"| within (10m0s) |"},
+		},
+		{
+			name:    "exceeded",
+			budget:  time.Minute,
+			outputs: "total_duration=300\nslowest_step=build > go test | tee\nslowest_step_duration=180\nbudget_status=exceeded\n",
+			summary: []string{"| exceeded (1m0s) |"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fs := afero.NewMemMapFs()
+			r := &Runner{
+				fs:           fs,
+				report:       bytes.NewBufferString("## Workflow Run\n\n<table></table>\n"),
+				analyzedRuns: newTestRuns(),
+			}
+			input := &collector.Input{
+				OutputFile:      "/github/output",
+				StepSummaryFile: "/github/step_summary",
+				Budget:          tt.budget,
+			}
+			if err := r.writeActionsReport(input); err != nil {
+				t.Fatal(err)
+			}
+			outputs, err := afero.ReadFile(fs, input.OutputFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(outputs) != tt.outputs {
+				t.Errorf("outputs = %q, want %q", outputs, tt.outputs)
+			}
+			b, err := afero.ReadFile(fs, input.StepSummaryFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			summary := string(b)
+			if !strings.HasPrefix(summary, "## ghaperf\n\n| Total Duration |") {
+				t.Errorf("the summary table should come first: %q", summary)
+			}
+			if !strings.HasSuffix(summary, "<details>\n<summary>Report</summary>\n\n## Workflow Run\n\n<table></table>\n\n</details>\n\n") {
+				t.Errorf("the report should be collapsed: %q", summary)
+			}
+			for _, s := range tt.summary {
+				if !strings.Contains(summary, s) {
+					t.Errorf("the summary should contain %q: %q", s, summary)
+				}
+			}
+		})
+	}
+}
+
+func TestRunner_writeActionsReport_disabled(t *testing.T) {
+	t.Parallel()
+	fs := afero.NewMemMapFs()
+	r := &Runner{fs: fs, analyzedRuns: newTestRuns()}
+	if err := r.writeActionsReport(&collector.Input{}); err != nil {
+		t.Fatal(err)
+	}
+	files, err := afero.ReadDir(fs, "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 0 {
+		t.Errorf("no file should be written: %d", len(files))
+	}
+}
@@ -1,11 +1,20 @@
 package runner
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/spf13/afero"
+
 	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
 	"github.com/suzuki-shunsuke/ghaperf/pkg/parser"
 	"github.com/suzuki-shunsuke/ghaperf/pkg/view"
@@ -23,14 +32,28 @@ func (r *Runner) Run(ctx context.Context, logger *slog.Logger, input *collector.
 		WorkflowName:            input.WorkflowName,
 		Config:                  input.Config,
 	}
+	if err := r.run(ctx, logger, input, headerArg); err != nil {
+		return err
+	}
+	if err := r.writeActionsReport(input); err != nil {
+		return err
+	}
+	if input.Comment {
+		return r.postComment(ctx, logger, input)
+	}
+	return nil
+}
+
+func (r *Runner) run(ctx context.Context, logger *slog.Logger, input *collector.Input, headerArg *view.HeaderArg) error {
 	if input.JobID != 0 {
 		job, err := r.collector.GetJob(ctx, logger, input, input.JobID)
 		if err != nil {
 			return fmt.Errorf("run job ID %d: %w", input.JobID, err)
 		}
+		r.analyzedRuns = []*collector.WorkflowRun{{Jobs: []*collector.Job{job}}}
 		r.viewer.ShowHeader(headerArg)
 		r.viewer.ShowJob(job, input.Threshold)
-		return nil
+		return r.viewerErr()
 	}
 	if input.RunID != 0 {
 		return r.runWithRunID(ctx, logger, input, headerArg)
@@ -38,22 +61,151 @@ func (r *Runner) Run(ctx context.Context, logger *slog.Logger, input *collector.
 	return r.runs(ctx, logger, input, headerArg)
 }
 
-func (r *Runner) RunWithLogFile(input *collector.Input) error {
-	f, err := r.fs.Open(input.LogFile)
+var (
+	errArchiveWithLogFiles = errors.New("a log archive can't be analyzed with other log files")
+	errStdinWithLogFiles   = errors.New("the standard input can't be analyzed with other log files")
+)
+
+func (r *Runner) RunWithLogFile(logger *slog.Logger, input *collector.Input) error {
+	if err := r.runWithLogFile(logger, input); err != nil {
+		return err
+	}
+	return r.writeActionsReport(input)
+}
+
+func (r *Runner) runWithLogFile(logger *slog.Logger, input *collector.Input) error {
+	if slices.Contains(input.LogFiles, "-") {
+		if len(input.LogFiles) > 1 {
+			return errStdinWithLogFiles
+		}
+		return r.runWithStdin(logger, input)
+	}
+	paths, err := collector.ExpandLogFiles(r.fs, input.LogFiles)
 	if err != nil {
-		return fmt.Errorf("open a log file: %w", slogerr.With(err, "log_file", input.LogFile))
+		return err //nolint:wrapcheck
+	}
+	for _, path := range paths {
+		isArchive, err := collector.IsLogArchive(r.fs, path)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+		if !isArchive {
+			continue
+		}
+		if len(paths) > 1 {
+			return slogerr.With(errArchiveWithLogFiles, "log_file", path) //nolint:wrapcheck
+		}
+		return r.runWithLogArchive(logger, input, path)
+	}
+	if len(paths) > 1 {
+		return r.runWithLogFiles(logger, input, paths)
+	}
+	f, err := r.fs.Open(paths[0])
+	if err != nil {
+		return fmt.Errorf("open a log file: %w", slogerr.With(err, "log_file", paths[0]))
 	}
 	defer f.Close()
-	log, err := parser.Parse(f)
+	return r.runWithLogReader(logger, input, strings.TrimSuffix(filepath.Base(paths[0]), filepath.Ext(paths[0])), f)
+}
+
+var zipMagic = []byte("PK\x03\x04")
+
+// stdinName is the workflow run name of logs read from the standard input.
+const stdinName = "stdin"
+
+// runWithStdin analyzes a log read from the standard input.
+// A log archive like the output of `gh api repos/{owner}/{repo}/actions/runs/{run_id}/logs` is written to a temporary file because zip requires random access.
+func (r *Runner) runWithStdin(logger *slog.Logger, input *collector.Input) error {
+	br := bufio.NewReader(r.stdin)
+	head, err := br.Peek(len(zipMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("read the standard input: %w", err)
+	}
+	if !bytes.Equal(head, zipMagic) {
+		return r.runWithLogReader(logger, input, stdinName, br)
+	}
+	f, err := afero.TempFile(r.fs, "", "ghaperf-log-*.zip")
+	if err != nil {
+		return fmt.Errorf("create a temporary file for a log archive: %w", err)
+	}
+	defer func() {
+		if err := r.fs.Remove(f.Name()); err != nil {
+			slogerr.WithError(logger, err).Warn("remove a temporary file of a log archive", "path", f.Name())
+		}
+	}()
+	if _, err := io.Copy(f, br); err != nil {
+		f.Close()
+		return fmt.Errorf("write a log archive to a temporary file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("close a temporary file of a log archive: %w", err)
+	}
+	run, err := r.collector.ReadLogArchive(logger, input, f.Name())
+	if err != nil {
+		return fmt.Errorf("read a log archive: %w", err)
+	}
+	name := stdinName
+	run.Run.Name = &name
+	return r.showLogRun(input, run)
+}
+
+// runWithLogReader analyzes a log.
+// The output of `gh run view --log` is shown like a workflow run because it has job and step names.
+func (r *Runner) runWithLogReader(logger *slog.Logger, input *collector.Input, name string, reader io.Reader) error {
+	logs, err := parser.ParseJobs(reader)
 	if err != nil {
 		return fmt.Errorf("parse a log file: %w", err)
 	}
+	for _, log := range logs {
+		collector.WarnLineErrors(logger, log, "job_name", log.JobName)
+	}
+	if len(logs) > 1 || logs[0].Prefixed {
+		return r.showLogRun(input, collector.NewRunFromLogs(input, name, logs))
+	}
 	r.viewer.ShowHeader(&view.HeaderArg{
 		Version:                 input.Version,
 		Now:                     time.Now(),
 		Threshold:               input.Threshold,
 		ListWorkflowRunsOptions: input.ListWorkflowRunsOptions,
 	})
-	r.viewer.ShowGroups(log.Groups, input.Threshold)
-	return nil
+	r.viewer.ShowGroups(logs[0].Groups, input.Threshold)
+	return r.viewerErr()
+}
+
+// runWithLogArchive shows the report of a log archive of a workflow run like --run-id.
+func (r *Runner) runWithLogArchive(logger *slog.Logger, input *collector.Input, path string) error {
+	run, err := r.collector.ReadLogArchive(logger, input, path)
+	if err != nil {
+		return fmt.Errorf("read a log archive: %w", err)
+	}
+	return r.showLogRun(input, run)
+}
+
+// showLogRun shows the report of a workflow run built from logs.
+func (r *Runner) showLogRun(input *collector.Input, run *collector.WorkflowRun) error {
+	r.analyzedRuns = []*collector.WorkflowRun{run}
+	r.viewer.ShowHeader(&view.HeaderArg{
+		Version:   input.Version,
+		Now:       time.Now(),
+		Threshold: input.Threshold,
+		Config:    input.Config,
+	})
+	r.viewer.ShowRun(run, input.Threshold)
+	if input.Hangs {
+		r.viewer.ShowHangs([]*collector.WorkflowRun{run}, input.Threshold)
+	}
+	return r.viewerErr()
+}
+
+// runWithLogFiles aggregates durations of log groups of multiple log files by the job name.
+func (r *Runner) runWithLogFiles(logger *slog.Logger, input *collector.Input, paths []string) error {
+	jobs := r.collector.ReadLogFiles(logger, input, paths)
+	r.viewer.ShowHeader(&view.HeaderArg{
+		Version:   input.Version,
+		Now:       time.Now(),
+		Threshold: input.Threshold,
+		Config:    input.Config,
+	})
+	r.viewer.ShowLogGroups(jobs, input.Threshold)
+	return r.viewerErr()
 }
@@ -1,7 +1,7 @@
 package runner
 
 import (
-	"archive/zip"
+	"bytes"
 	"context"
 	"io"
 	"log/slog"
@@ -9,6 +9,7 @@ import (
 
 	"github.com/spf13/afero"
 	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/config"
 	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
 	"github.com/suzuki-shunsuke/ghaperf/pkg/parser"
 	"github.com/suzuki-shunsuke/ghaperf/pkg/view"
@@ -16,10 +17,16 @@ import (
 
 type Runner struct {
 	gh        GitHub
+	stdin     io.Reader
 	stdout    io.Writer
 	fs        afero.Fs
 	viewer    Viewer
 	collector Collector
+	// report is a copy of the report to post it as a pull request comment or write it to the job summary
+	report      *bytes.Buffer
+	pullRequest int
+	// analyzedRuns are workflow runs for step outputs and the job summary of GitHub Actions
+	analyzedRuns []*collector.WorkflowRun
 }
 
 type GitHub interface {
@@ -28,7 +35,11 @@ type GitHub interface {
 	GetWorkflowRunByID(ctx context.Context, owner, repo string, runID int64, attempt int) (*github.WorkflowRun, error)
 	ListWorkflowJobs(ctx context.Context, owner, repo string, runID int64, attempt int) ([]*github.WorkflowJob, error)
 	ListWorkflowRuns(ctx context.Context, owner, repo string, fileName string, maxCount int, opts *github.ListWorkflowRunsOptions) ([]*github.WorkflowRun, error)
-	GetWorkflowRunLogs(ctx context.Context, owner, repo string, runID int64, attempt int) ([]*zip.File, error)
+	GetWorkflowRunLogs(ctx context.Context, owner, repo string, runID int64, attempt int) (*github.RunLogs, error)
+	ListIssueComments(ctx context.Context, owner, repo string, number int) ([]*github.IssueComment, error)
+	CreateIssueComment(ctx context.Context, owner, repo string, number int, body string) error
+	EditIssueComment(ctx context.Context, owner, repo string, commentID int64, body string) error
+	ListPullRequestsWithCommit(ctx context.Context, owner, repo, sha string) ([]*github.PullRequest, error)
 }
 
 type Viewer interface {
@@ -37,26 +48,78 @@ type Viewer interface {
 	ShowGroups(groups []*parser.Group, threshold time.Duration)
 	ShowRun(run *collector.WorkflowRun, threshold time.Duration)
 	ShowRuns(runs []*collector.WorkflowRun, threshold time.Duration)
+	ShowActions(runs []*collector.WorkflowRun, threshold time.Duration)
+	ShowCost(runs []*collector.WorkflowRun, threshold time.Duration, cfg *config.Config)
+	ShowTrend(runs []*collector.WorkflowRun, threshold time.Duration, bucket, stat string)
+	ShowChangePoints(cps []*view.ChangePoint)
+	ShowAttempts(runs, attempts []*collector.WorkflowRun)
+	ShowConclusions(runs []*collector.WorkflowRun, threshold time.Duration)
+	ShowHangs(runs []*collector.WorkflowRun, threshold time.Duration)
+	ShowLogGroups(jobs []*collector.Job, threshold time.Duration)
 }
 
 type Collector interface {
-	GetJobLog(ctx context.Context, input *collector.Input, jobID int64) ([]byte, error)
+	GetJobLog(ctx context.Context, input *collector.Input, jobID int64) (*parser.Log, error)
 	GetJob(ctx context.Context, logger *slog.Logger, input *collector.Input, jobID int64) (*collector.Job, error)
 	GetRun(ctx context.Context, logger *slog.Logger, input *collector.Input, runID int64, attempt int) (*collector.WorkflowRun, error)
 	ListRuns(ctx context.Context, logger *slog.Logger, input *collector.Input, maxCount int) ([]*collector.WorkflowRun, error)
+	ListAttempts(ctx context.Context, logger *slog.Logger, input *collector.Input, run *github.WorkflowRun) ([]*collector.WorkflowRun, error)
+	ReadLogArchive(logger *slog.Logger, input *collector.Input, path string) (*collector.WorkflowRun, error)
+	ReadLogFiles(logger *slog.Logger, input *collector.Input, paths []string) []*collector.Job
+}
+
+const (
+	FormatHTML = "html"
+	FormatCSV  = "csv"
+	FormatTSV  = "tsv"
+)
+
+func newViewer(format string, stdout io.Writer) Viewer {
+	switch format {
+	case FormatHTML:
+		return view.NewHTML(stdout)
+	case FormatCSV:
+		return view.NewCSV(stdout, ',')
+	case FormatTSV:
+		return view.NewCSV(stdout, '\t')
+	default:
+		return view.New(stdout)
+	}
+}
+
+// viewerErr returns an error which occurred while the viewer renders the report.
+// The Markdown viewer ignores errors like fmt.Fprintf, but other viewers record the first error.
+func (r *Runner) viewerErr() error {
+	v, ok := r.viewer.(interface{ Err() error })
+	if !ok {
+		return nil
+	}
+	return v.Err() //nolint:wrapcheck
 }
 
 type Args struct {
-	Stdout io.Writer
-	Fs     afero.Fs
+	Stdin   io.Reader
+	Stdout  io.Writer
+	Fs      afero.Fs
+	Format  string
+	Comment bool
+	// JobSummary is true if the report is written to the job summary of GitHub Actions
+	JobSummary bool
 }
 
 func NewRunner(gh GitHub, args *Args) *Runner {
-	return &Runner{
+	r := &Runner{
 		gh:        gh,
+		stdin:     args.Stdin,
 		stdout:    args.Stdout,
 		fs:        args.Fs,
-		viewer:    view.New(args.Stdout),
 		collector: collector.New(args.Fs, gh),
 	}
+	stdout := args.Stdout
+	if args.Comment || args.JobSummary {
+		r.report = &bytes.Buffer{}
+		stdout = io.MultiWriter(stdout, r.report)
+	}
+	r.viewer = newViewer(args.Format, stdout)
+	return r
 }
@@ -20,9 +20,30 @@ func (r *Runner) runWithRunID(ctx context.Context, logger *slog.Logger, input *c
 		}
 		slogerr.WithError(logger, err).Warn("get run by run id")
 	}
+	runs := []*collector.WorkflowRun{run}
+	r.recordHistory(logger, input, runs)
+	r.setPullRequest(run)
+	// --by-conclusion and --attempts compare jobs with different conclusions, so jobs aren't filtered by the conclusion
+	filteredRuns := view.FilterJobConclusions(runs, input.Config)
+	r.analyzedRuns = filteredRuns
 	r.viewer.ShowHeader(headerArg)
-	r.viewer.ShowRun(run, input.Threshold)
-	return nil
+	r.viewer.ShowRun(filteredRuns[0], input.Threshold)
+	if input.ActionReport {
+		r.viewer.ShowActions(filteredRuns, input.Threshold)
+	}
+	if input.CostReport {
+		r.viewer.ShowCost(filteredRuns, input.Threshold, input.Config)
+	}
+	if input.ByConclusion {
+		r.viewer.ShowConclusions(runs, input.Threshold)
+	}
+	if input.Hangs {
+		r.viewer.ShowHangs(filteredRuns, input.Threshold)
+	}
+	if input.Attempts {
+		r.viewer.ShowAttempts(runs, r.listAttempts(ctx, logger, input, runs))
+	}
+	return r.viewerErr()
 }
 
 func (r *Runner) runs(ctx context.Context, logger *slog.Logger, input *collector.Input, headerArg *view.HeaderArg) error {
@@ -30,7 +51,33 @@ func (r *Runner) runs(ctx context.Context, logger *slog.Logger, input *collector
 	if err != nil {
 		return fmt.Errorf("list workflow runs: %w", err)
 	}
+	r.recordHistory(logger, input, runs)
+	filteredRuns := view.FilterJobConclusions(runs, input.Config)
+	r.analyzedRuns = filteredRuns
 	r.viewer.ShowHeader(headerArg)
-	r.viewer.ShowRuns(runs, input.Threshold)
-	return nil
+	r.viewer.ShowRuns(filteredRuns, input.Threshold)
+	if input.Trend != "" {
+		r.viewer.ShowTrend(filteredRuns, input.Threshold, input.Trend, input.TrendStat)
+	}
+	if input.ChangePoint {
+		cps := view.GetChangePoints(filteredRuns, input.Threshold)
+		r.resolvePullRequests(ctx, logger, input, cps)
+		r.viewer.ShowChangePoints(cps)
+	}
+	if input.ActionReport {
+		r.viewer.ShowActions(filteredRuns, input.Threshold)
+	}
+	if input.CostReport {
+		r.viewer.ShowCost(filteredRuns, input.Threshold, input.Config)
+	}
+	if input.ByConclusion {
+		r.viewer.ShowConclusions(runs, input.Threshold)
+	}
+	if input.Hangs {
+		r.viewer.ShowHangs(filteredRuns, input.Threshold)
+	}
+	if input.Attempts {
+		r.viewer.ShowAttempts(runs, r.listAttempts(ctx, logger, input, runs))
+	}
+	return r.viewerErr()
 }
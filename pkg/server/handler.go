@@ -0,0 +1,357 @@
+package server
+
+import (
+	"cmp"
+	"errors"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/parser"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/view"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+const (
+	defaultCount = 100
+	maxLines     = 100
+)
+
+type Workflow struct {
+	Name        string
+	File        string
+	Count       int
+	LastCreated time.Time
+}
+
+type Repo struct {
+	Owner     string
+	Name      string
+	Workflows []*Workflow
+}
+
+type IndexPage struct {
+	Title string
+	Repos []*Repo
+}
+
+// pathValue returns a path parameter which is used as a file name in the cache directory.
+// Path parameters are decoded, so "%2e%2e" and "%2F" are rejected to prevent path traversal.
+func pathValue(r *http.Request, name string) (string, bool) {
+	v := r.PathValue(name)
+	if v == "" || v == "." || v == ".." || strings.ContainsAny(v, `/\`) {
+		return "", false
+	}
+	return v, true
+}
+
+func (s *Server) repoInput(w http.ResponseWriter, r *http.Request) (*collector.Input, bool) {
+	owner, ok := pathValue(r, "owner")
+	if !ok {
+		http.Error(w, "invalid repository owner", http.StatusBadRequest)
+		return nil, false
+	}
+	repo, ok := pathValue(r, "repo")
+	if !ok {
+		http.Error(w, "invalid repository name", http.StatusBadRequest)
+		return nil, false
+	}
+	input := *s.input
+	input.RepoOwner = owner
+	input.RepoName = repo
+	return &input, true
+}
+
+func (s *Server) handleIndex(w http.ResponseWriter, _ *http.Request) {
+	repos, err := s.collector.ListCachedRepos(s.input.CacheDir)
+	if err != nil {
+		s.error(w, err, "list cached repositories", http.StatusInternalServerError)
+		return
+	}
+	page := &IndexPage{
+		Title: "ghaperf",
+		Repos: make([]*Repo, 0, len(repos)),
+	}
+	for _, repo := range repos {
+		input := *s.input
+		input.RepoOwner = repo.Owner
+		input.RepoName = repo.Name
+		runs, err := s.collector.ListCachedRuns(s.logger, &input)
+		if err != nil {
+			s.error(w, err, "list cached workflow runs", http.StatusInternalServerError)
+			return
+		}
+		page.Repos = append(page.Repos, &Repo{
+			Owner:     repo.Owner,
+			Name:      repo.Name,
+			Workflows: groupByWorkflow(runs),
+		})
+	}
+	s.render(w, "index.html", page)
+}
+
+func groupByWorkflow(runs []*github.WorkflowRun) []*Workflow {
+	workflows := map[string]*Workflow{}
+	for _, run := range runs {
+		file := path.Base(run.GetPath())
+		wf, ok := workflows[file]
+		if !ok {
+			wf = &Workflow{
+				Name: run.GetName(),
+				File: file,
+			}
+			workflows[file] = wf
+		}
+		wf.Count++
+		if createdAt := run.GetCreatedAt().Time; createdAt.After(wf.LastCreated) {
+			wf.LastCreated = createdAt
+		}
+	}
+	arr := make([]*Workflow, 0, len(workflows))
+	for _, wf := range workflows {
+		arr = append(arr, wf)
+	}
+	slices.SortFunc(arr, func(a, b *Workflow) int {
+		return cmp.Compare(a.File, b.File)
+	})
+	return arr
+}
+
+type WorkflowPage struct {
+	Title    string
+	Owner    string
+	Repo     string
+	Workflow string
+	Runs     []*Run
+	Jobs     []*view.JobMetric
+}
+
+type Run struct {
+	Run      *github.WorkflowRun
+	Duration time.Duration
+	Jobs     []*Job
+}
+
+func (s *Server) handleWorkflow(w http.ResponseWriter, r *http.Request) {
+	input, ok := s.repoInput(w, r)
+	if !ok {
+		return
+	}
+	workflow, ok := pathValue(r, "workflow")
+	if !ok {
+		http.Error(w, "invalid workflow", http.StatusBadRequest)
+		return
+	}
+	count := defaultCount
+	if c := r.URL.Query().Get("count"); c != "" {
+		n, err := strconv.Atoi(c)
+		if err != nil || n <= 0 {
+			http.Error(w, "count must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		count = n
+	}
+	runs, err := s.collector.ListCachedRuns(s.logger, input)
+	if err != nil {
+		s.error(w, err, "list cached workflow runs", http.StatusInternalServerError)
+		return
+	}
+	runs = slices.DeleteFunc(runs, func(run *github.WorkflowRun) bool {
+		return path.Base(run.GetPath()) != workflow
+	})
+	slices.SortFunc(runs, func(a, b *github.WorkflowRun) int {
+		return b.GetCreatedAt().Compare(a.GetCreatedAt().Time)
+	})
+	if len(runs) > count {
+		runs = runs[:count]
+	}
+	page := &WorkflowPage{
+		Title:    workflow,
+		Owner:    input.RepoOwner,
+		Repo:     input.RepoName,
+		Workflow: workflow,
+		Runs:     make([]*Run, 0, len(runs)),
+	}
+	workflowRuns := make([]*collector.WorkflowRun, 0, len(runs))
+	for _, run := range runs {
+		wr, err := s.collector.GetCachedRun(s.logger, input, run.GetID(), run.GetRunAttempt())
+		if err != nil {
+			slogerr.WithError(s.logger, err).Warn("get a cached workflow run", "run_id", run.GetID())
+			continue
+		}
+		workflowRuns = append(workflowRuns, wr)
+		page.Runs = append(page.Runs, &Run{
+			Run:      run,
+			Duration: runDuration(run),
+		})
+	}
+	page.Jobs = view.AggregateRuns(workflowRuns)
+	slices.SortFunc(page.Jobs, func(a, b *view.JobMetric) int {
+		return cmp.Compare(b.Metric.Sum, a.Metric.Sum)
+	})
+	s.render(w, "workflow.html", page)
+}
+
+func runDuration(run *github.WorkflowRun) time.Duration {
+	if run.GetStatus() != "completed" {
+		return 0
+	}
+	return run.GetUpdatedAt().Sub(run.GetRunStartedAt().Time)
+}
+
+type RunPage struct {
+	Title string
+	Owner string
+	Repo  string
+	Run   *Run
+}
+
+type Job struct {
+	Job   *collector.Job
+	Bar   *view.Bar
+	Steps []*Step
+}
+
+type Step struct {
+	Step   *view.Step
+	Bar    *view.Bar
+	Groups []*Group
+}
+
+type Group struct {
+	Name      string
+	Duration  time.Duration
+	Lines     []string
+	Truncated bool
+}
+
+func (s *Server) handleRun(w http.ResponseWriter, r *http.Request) {
+	input, ok := s.repoInput(w, r)
+	if !ok {
+		return
+	}
+	runID, err := strconv.ParseInt(r.PathValue("run_id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid run id", http.StatusBadRequest)
+		return
+	}
+	attempt, err := strconv.Atoi(r.PathValue("attempt"))
+	if err != nil {
+		http.Error(w, "invalid run attempt", http.StatusBadRequest)
+		return
+	}
+	wr, err := s.collector.GetCachedRun(s.logger, input, runID, attempt)
+	if err != nil {
+		if errors.Is(err, collector.ErrNotCached) {
+			http.NotFound(w, r)
+			return
+		}
+		s.error(w, err, "get a cached workflow run", http.StatusInternalServerError)
+		return
+	}
+	slices.SortFunc(wr.Jobs, func(a, b *collector.Job) int {
+		return a.Job.GetStartedAt().Compare(b.Job.GetStartedAt().Time)
+	})
+	var start, end time.Time
+	for _, job := range wr.Jobs {
+		startedAt := job.Job.GetStartedAt().Time
+		completedAt := job.Job.GetCompletedAt().Time
+		if start.IsZero() || startedAt.Before(start) {
+			start = startedAt
+		}
+		if completedAt.After(end) {
+			end = completedAt
+		}
+	}
+	run := &Run{
+		Run:      wr.Run,
+		Duration: runDuration(wr.Run),
+		Jobs:     make([]*Job, len(wr.Jobs)),
+	}
+	for i, job := range wr.Jobs {
+		run.Jobs[i] = &Job{
+			Job: job,
+			Bar: view.NewBar(job.Job.GetStartedAt().Time, job.Job.GetCompletedAt().Time, start, end.Sub(start)),
+		}
+	}
+	s.render(w, "run.html", &RunPage{
+		Title: wr.Run.GetName(),
+		Owner: input.RepoOwner,
+		Repo:  input.RepoName,
+		Run:   run,
+	})
+}
+
+type JobPage struct {
+	Title string
+	Owner string
+	Repo  string
+	Job   *Job
+}
+
+// newGroup converts a log group to a view model.
+// Only the first lines of the group are kept as an excerpt.
+func newGroup(name string, d time.Duration, lines []*parser.Line) *Group {
+	group := &Group{
+		Name:     name,
+		Duration: d,
+	}
+	for i, line := range lines {
+		if i == maxLines {
+			group.Truncated = true
+			break
+		}
+		group.Lines = append(group.Lines, line.Timestamp.Format("15:04:05.000")+" "+line.Content)
+	}
+	return group
+}
+
+func (s *Server) handleJob(w http.ResponseWriter, r *http.Request) {
+	input, ok := s.repoInput(w, r)
+	if !ok {
+		return
+	}
+	jobID, err := strconv.ParseInt(r.PathValue("job_id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid job id", http.StatusBadRequest)
+		return
+	}
+	job, err := s.collector.GetCachedJob(s.logger, input, jobID)
+	if err != nil {
+		if errors.Is(err, collector.ErrNotCached) {
+			http.NotFound(w, r)
+			return
+		}
+		s.error(w, err, "get a cached job", http.StatusInternalServerError)
+		return
+	}
+	start := job.Job.GetStartedAt().Time
+	d := job.Job.GetCompletedAt().Sub(start)
+	steps := view.JobSteps(job)
+	page := &JobPage{
+		Title: job.Job.GetName(),
+		Owner: input.RepoOwner,
+		Repo:  input.RepoName,
+		Job: &Job{
+			Job:   job,
+			Steps: make([]*Step, len(steps)),
+		},
+	}
+	for i, step := range steps {
+		groups := make([]*Group, len(step.Groups))
+		for j, group := range step.Groups {
+			groups[j] = newGroup(group.Name, group.Duration(), group.Lines)
+		}
+		page.Job.Steps[i] = &Step{
+			Step:   step,
+			Bar:    view.NewBar(step.StartTime, step.EndTime, start, d),
+			Groups: groups,
+		}
+	}
+	s.render(w, "job.html", page)
+}
@@ -0,0 +1,214 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/cache"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/config"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/xdg"
+)
+
+const (
+	testCacheDir = "/cache"
+	testOwner    = "suzuki-shunsuke"
+	testRepo     = "ghaperf"
+)
+
+func writeJSON(t *testing.T, fs afero.Fs, path string, v any) {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := afero.WriteFile(fs, path, b, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newTestServer(t *testing.T) http.Handler {
+	t.Helper()
+	fs := afero.NewMemMapFs()
+	start := time.Date(2025, 10, 25, 13, 0, 0, 0, time.UTC)
+	var runID, jobID int64 = 1, 10
+	attempt := 1
+	name := "test"
+	path := ".github/workflows/test.yaml"
+	status := "completed"
+	conclusion := "success"
+	writeJSON(t, fs, xdg.RunCache(testCacheDir, testOwner, testRepo, runID, attempt), &github.WorkflowRun{
+		ID:           &runID,
+		RunAttempt:   &attempt,
+		Name:         &name,
+		Path:         &path,
+		Status:       &status,
+		Conclusion:   &conclusion,
+		CreatedAt:    &github.Timestamp{Time: start},
+		RunStartedAt: &github.Timestamp{Time: start},
+		UpdatedAt:    &github.Timestamp{Time: start.Add(time.Minute)},
+	})
+	writeJSON(t, fs, xdg.RunJobIDsCache(testCacheDir, testOwner, testRepo, runID, attempt), []int64{jobID})
+	jobName := "build <script>"
+	stepName := "Run make"
+	jobCachePath := xdg.JobCache(testCacheDir, testOwner, testRepo, jobID)
+	writeJSON(t, fs, jobCachePath, &github.WorkflowJob{
+		ID:          &jobID,
+		RunID:       &runID,
+		Name:        &jobName,
+		Status:      &status,
+		Conclusion:  &conclusion,
+		StartedAt:   &github.Timestamp{Time: start},
+		CompletedAt: &github.Timestamp{Time: start.Add(time.Minute)},
+		Steps: []*github.TaskStep{
+			{
+				Name:        &stepName,
+				StartedAt:   &github.Timestamp{Time: start},
+				CompletedAt: &github.Timestamp{Time: start.Add(time.Minute)},
+			},
+		},
+	})
+	f, err := cache.Create(fs, xdg.JobLogCache(jobCachePath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.Write([]byte("2025-10-25T13:00:01.0000000Z ##[group]go test\n2025-10-25T13:00:02.0000000Z ok\n2025-10-25T13:00:30.0000000Z ##[endgroup]\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := New(slog.New(slog.DiscardHandler), collector.New(fs, &github.Offline{}), &collector.Input{
+		CacheDir: testCacheDir,
+		Config:   &config.Config{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler, err := s.handler()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return handler
+}
+
+func TestServer_handler(t *testing.T) {
+	t.Parallel()
+	handler := newTestServer(t)
+	tests := []struct {
+		name     string
+		path     string
+		code     int
+		contains []string
+	}{
+		{
+			name:     "index",
+			path:     "/",
+			code:     http.StatusOK,
+			contains: []string{"suzuki-shunsuke/ghaperf", "/repos/suzuki-shunsuke/ghaperf/workflows/test.yaml"},
+		},
+		{
+			name:     "workflow",
+			path:     "/repos/suzuki-shunsuke/ghaperf/workflows/test.yaml",
+			code:     http.StatusOK,
+			contains: []string{"build &lt;script&gt;"},
+		},
+		{
+			name:     "run",
+			path:     "/repos/suzuki-shunsuke/ghaperf/runs/1/1",
+			code:     http.StatusOK,
+			contains: []string{"/repos/suzuki-shunsuke/ghaperf/jobs/10", "build &lt;script&gt;"},
+		},
+		{
+			name:     "job",
+			path:     "/repos/suzuki-shunsuke/ghaperf/jobs/10",
+			code:     http.StatusOK,
+			contains: []string{"Run make", "go test"},
+		},
+		{
+			name: "run not found",
+			path: "/repos/suzuki-shunsuke/ghaperf/runs/2/1",
+			code: http.StatusNotFound,
+		},
+		{
+			name: "job not found",
+			path: "/repos/suzuki-shunsuke/ghaperf/jobs/11",
+			code: http.StatusNotFound,
+		},
+		{
+			name: "invalid run id",
+			path: "/repos/suzuki-shunsuke/ghaperf/runs/foo/1",
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "invalid run attempt",
+			path: "/repos/suzuki-shunsuke/ghaperf/runs/1/foo",
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "invalid job id",
+			path: "/repos/suzuki-shunsuke/ghaperf/jobs/foo",
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "invalid count",
+			path: "/repos/suzuki-shunsuke/ghaperf/workflows/test.yaml?count=0",
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "path separator in the owner",
+			path: "/repos/..%5C..%5Cetc/ghaperf/jobs/10",
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "path separator in the repository",
+			path: "/repos/suzuki-shunsuke/a%2F..%2F..%2Fghaperf/runs/1/1",
+			code: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if rec.Code != tt.code {
+				t.Fatalf("status code = %d, want %d: %s", rec.Code, tt.code, rec.Body.String())
+			}
+			body := rec.Body.String()
+			for _, s := range tt.contains {
+				if !strings.Contains(body, s) {
+					t.Errorf("the response doesn't contain %q:\n%s", s, body)
+				}
+			}
+		})
+	}
+}
+
+func TestPathValue(t *testing.T) {
+	t.Parallel()
+	for _, v := range []string{"", ".", "..", "a/b", `a\b`} {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.SetPathValue("owner", v)
+		if _, ok := pathValue(r, "owner"); ok {
+			t.Errorf("%q should be rejected", v)
+		}
+	}
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.SetPathValue("owner", "suzuki-shunsuke")
+	if v, ok := pathValue(r, "owner"); !ok || v != "suzuki-shunsuke" {
+		t.Errorf("pathValue() = %q, %v, want suzuki-shunsuke, true", v, ok)
+	}
+}
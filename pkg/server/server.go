@@ -0,0 +1,131 @@
+package server
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+//go:embed static
+var staticFS embed.FS
+
+type Server struct {
+	logger    *slog.Logger
+	collector Collector
+	input     *collector.Input
+	templates map[string]*template.Template
+}
+
+type Collector interface {
+	ListCachedRepos(cacheDir string) ([]*collector.Repo, error)
+	ListCachedRuns(logger *slog.Logger, input *collector.Input) ([]*github.WorkflowRun, error)
+	GetCachedRun(logger *slog.Logger, input *collector.Input, runID int64, attempt int) (*collector.WorkflowRun, error)
+	GetCachedJob(logger *slog.Logger, input *collector.Input, jobID int64) (*collector.Job, error)
+}
+
+func New(logger *slog.Logger, clt Collector, input *collector.Input) (*Server, error) {
+	templates, err := parseTemplates()
+	if err != nil {
+		return nil, err
+	}
+	return &Server{
+		logger:    logger,
+		collector: clt,
+		input:     input,
+		templates: templates,
+	}, nil
+}
+
+var pages = []string{"index.html", "workflow.html", "run.html", "job.html"} //nolint:gochecknoglobals
+
+func parseTemplates() (map[string]*template.Template, error) {
+	templates := make(map[string]*template.Template, len(pages))
+	for _, page := range pages {
+		tpl, err := template.New(page).Funcs(template.FuncMap{
+			"duration": formatDuration,
+			"seconds":  func(d time.Duration) int64 { return int64(d.Seconds()) },
+			"time":     formatTime,
+		}).ParseFS(templateFS, "templates/layout.html", "templates/"+page)
+		if err != nil {
+			return nil, fmt.Errorf("parse a template: %w", err)
+		}
+		templates[page] = tpl
+	}
+	return templates, nil
+}
+
+const readHeaderTimeout = 10 * time.Second
+
+func (s *Server) handler() (http.Handler, error) {
+	static, err := fs.Sub(staticFS, "static")
+	if err != nil {
+		return nil, fmt.Errorf("get static files: %w", err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET /static/", http.StripPrefix("/static/", http.FileServerFS(static)))
+	mux.HandleFunc("GET /{$}", s.handleIndex)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/workflows/{workflow}", s.handleWorkflow)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/runs/{run_id}/{attempt}", s.handleRun)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/jobs/{job_id}", s.handleJob)
+	return mux, nil
+}
+
+// ListenAndServe starts the HTTP server and stops it when ctx is canceled.
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	handler, err := s.handler()
+	if err != nil {
+		return err
+	}
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+	go func() {
+		<-ctx.Done()
+		if err := server.Shutdown(context.WithoutCancel(ctx)); err != nil {
+			slogerr.WithError(s.logger, err).Error("shut down the server")
+		}
+	}()
+	s.logger.Info("start the server", "url", "http://"+addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("listen and serve: %w", err)
+	}
+	return nil
+}
+
+func (s *Server) render(w http.ResponseWriter, page string, data any) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := s.templates[page].ExecuteTemplate(w, "layout", data); err != nil {
+		slogerr.WithError(s.logger, err).Error("render a page", "page", page)
+	}
+}
+
+func (s *Server) error(w http.ResponseWriter, err error, msg string, code int) {
+	slogerr.WithError(s.logger, err).Error(msg)
+	http.Error(w, msg, code)
+}
+
+func formatDuration(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
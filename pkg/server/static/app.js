@@ -0,0 +1,26 @@
+// Sort tables by clicking headers.
+// Cells with data-sort attributes are compared as numbers.
+document.querySelectorAll("table.sortable").forEach((table) => {
+  const headers = table.tHead ? table.tHead.rows[0].cells : [];
+  Array.from(headers).forEach((th, index) => {
+    th.addEventListener("click", () => {
+      const asc = !th.classList.contains("asc");
+      Array.from(headers).forEach((h) => h.classList.remove("asc", "desc"));
+      th.classList.add(asc ? "asc" : "desc");
+      const tbody = table.tBodies[0];
+      const rows = Array.from(tbody.rows);
+      rows.sort((a, b) => {
+        const x = a.cells[index];
+        const y = b.cells[index];
+        let c;
+        if (x.dataset.sort !== undefined && y.dataset.sort !== undefined) {
+          c = Number(x.dataset.sort) - Number(y.dataset.sort);
+        } else {
+          c = x.textContent.trim().localeCompare(y.textContent.trim());
+        }
+        return asc ? c : -c;
+      });
+      rows.forEach((row) => tbody.appendChild(row));
+    });
+  });
+});
@@ -0,0 +1,124 @@
+package view
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+)
+
+type ActionMetric struct {
+	Name   string
+	Metric *Metric
+	Groups map[string]*Metric
+}
+
+// Run actions/checkout@08c6903cd8c0fde910a37f88322edcfb5dd907a8
+// Post Run aquaproj/registry-action/test@68f10339de561d67f9acea40b91dc36aa5011ea8
+var actionStepName = regexp.MustCompile(`^(?:Post )?Run ([A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+(?:/[^@\s]+)?)@\S+$`)
+
+// getActionName returns the action identity (owner/repo[/path]) without the version.
+// If the step doesn't run an action, it returns an empty string.
+func getActionName(stepName string) string {
+	m := actionStepName.FindStringSubmatch(stepName)
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
+
+func getActionMetrics(runs []*collector.WorkflowRun, threshold time.Duration) []*ActionMetric {
+	actionMetrics := map[string]*ActionMetric{}
+	for _, run := range runs {
+		for _, job := range run.Jobs {
+			setActionMetricsByJob(actionMetrics, job)
+		}
+	}
+	return getSlowActions(slices.Collect(maps.Values(actionMetrics)), threshold)
+}
+
+func (v *Viewer) ShowActions(runs []*collector.WorkflowRun, threshold time.Duration) {
+	slowActions := getActionMetrics(runs, threshold)
+	if len(slowActions) == 0 {
+		fmt.Fprintln(v.stdout, "There is no slow action")
+		return
+	}
+	fmt.Fprintln(v.stdout, "## Actions")
+	for i, am := range slowActions {
+		fmt.Fprintf(v.stdout, "%d. %s (%s/%d): %s\n", i+1, am.Metric.Avg.Round(time.Second), am.Metric.Sum.Round(time.Second), am.Metric.Count, am.Name)
+		for j, gm := range am.SlowestGroups() {
+			fmt.Fprintf(v.stdout, "    %d. %s (%s/%d): %s\n", j+1, gm.Metric.Avg.Round(time.Second), gm.Metric.Sum.Round(time.Second), gm.Metric.Count, gm.Name)
+		}
+	}
+}
+
+func setActionMetricsByJob(actionMetrics map[string]*ActionMetric, job *collector.Job) {
+	if job.Job.GetStatus() != "completed" {
+		return
+	}
+	if job.Job.GetConclusion() == "skipped" {
+		return
+	}
+	for _, s := range job.Job.Steps {
+		name := getActionName(s.GetName())
+		if name == "" {
+			continue
+		}
+		am, ok := actionMetrics[name]
+		if !ok {
+			am = &ActionMetric{
+				Name:   name,
+				Metric: &Metric{},
+				Groups: map[string]*Metric{},
+			}
+			actionMetrics[name] = am
+		}
+		step := newStep(s, job.Groups)
+		am.Metric.Add(step.Duration())
+		for _, group := range step.Groups {
+			m, ok := am.Groups[group.Name]
+			if !ok {
+				m = &Metric{}
+				am.Groups[group.Name] = m
+			}
+			m.Add(group.Duration())
+		}
+	}
+}
+
+func getSlowActions(actions []*ActionMetric, threshold time.Duration) []*ActionMetric {
+	arr := make([]*ActionMetric, 0, len(actions))
+	for _, am := range actions {
+		// Even if each invocation is fast, an action which is used many times is worth optimizing.
+		if am.Metric.Sum < threshold {
+			continue
+		}
+		arr = append(arr, am)
+	}
+	sort.Slice(arr, func(i, j int) bool {
+		return arr[i].Metric.Sum > arr[j].Metric.Sum
+	})
+	return arr
+}
+
+// SlowestGroups returns the slowest log groups of the action by the average duration.
+func (am *ActionMetric) SlowestGroups() []*GroupMetric {
+	arr := make([]*GroupMetric, 0, len(am.Groups))
+	for name, m := range am.Groups {
+		arr = append(arr, &GroupMetric{
+			Name:   name,
+			Metric: m,
+		})
+	}
+	sort.Slice(arr, func(i, j int) bool {
+		return arr[i].Metric.Avg > arr[j].Metric.Avg
+	})
+	if len(arr) > countSlowest {
+		return arr[:countSlowest]
+	}
+	return arr
+}
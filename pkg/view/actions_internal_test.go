@@ -0,0 +1,46 @@
+package view
+
+import "testing"
+
+func Test_getActionName(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		stepName string
+		exp      string
+	}{
+		{
+			name:     "action",
+			stepName: "Run actions/checkout@08c6903cd8c0fde910a37f88322edcfb5dd907a8",
+			exp:      "actions/checkout",
+		},
+		{
+			name:     "action with path",
+			stepName: "Run aquaproj/registry-action/test@68f10339de561d67f9acea40b91dc36aa5011ea8",
+			exp:      "aquaproj/registry-action/test",
+		},
+		{
+			name:     "post",
+			stepName: "Post Run actions/checkout@v5",
+			exp:      "actions/checkout",
+		},
+		{
+			name:     "run step",
+			stepName: "Run lintnet lint",
+			exp:      "",
+		},
+		{
+			name:     "set up job",
+			stepName: "Set up job",
+			exp:      "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if name := getActionName(tt.stepName); name != tt.exp {
+				t.Errorf("getActionName() = %q, want %q", name, tt.exp)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package view
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+)
+
+type Retries struct {
+	Runs        int
+	RetriedRuns int
+	Attempts    int
+	// LostTime is the total duration of jobs in previous attempts
+	LostTime  time.Duration
+	TotalTime time.Duration
+	Jobs      []*RetriedJob
+}
+
+// LostRate returns the rate of runner time spent on previous attempts.
+func (r *Retries) LostRate() float64 {
+	if r.TotalTime <= 0 {
+		return 0
+	}
+	return float64(r.LostTime) / float64(r.TotalTime)
+}
+
+type RetriedJob struct {
+	Name        string
+	Failures    int
+	LostTime    time.Duration
+	FailedSteps []*FailedStep
+}
+
+type FailedStep struct {
+	Name  string
+	Count int
+}
+
+func isFailure(conclusion string) bool {
+	switch conclusion {
+	case "failure", "cancelled", "timed_out":
+		return true
+	default:
+		return false
+	}
+}
+
+// getRetries analyzes previous attempts of workflow runs.
+// runs are the latest attempts and attempts are previous attempts.
+// "Re-run failed jobs" carries successful jobs over to the new attempt with the same job IDs,
+// so jobs of previous attempts which are also in the latest attempts aren't lost time.
+func getRetries(runs, attempts []*collector.WorkflowRun) *Retries {
+	retries := &Retries{
+		Runs:     len(runs),
+		Attempts: len(attempts),
+	}
+	latestJobs := map[int64]struct{}{}
+	for _, run := range runs {
+		if run.Run.GetRunAttempt() > 1 {
+			retries.RetriedRuns++
+		}
+		for _, job := range run.Jobs {
+			retries.TotalTime += job.Duration()
+			if id := job.Job.GetID(); id != 0 {
+				latestJobs[id] = struct{}{}
+			}
+		}
+	}
+	jobs := map[string]*RetriedJob{}
+	failedSteps := map[string]map[string]int{}
+	for _, run := range attempts {
+		for _, job := range run.Jobs {
+			if _, ok := latestJobs[job.Job.GetID()]; ok {
+				continue
+			}
+			d := job.Duration()
+			retries.LostTime += d
+			retries.TotalTime += d
+			if !isFailure(job.Job.GetConclusion()) {
+				continue
+			}
+			rj, ok := jobs[job.NormalizedName]
+			if !ok {
+				rj = &RetriedJob{Name: job.NormalizedName}
+				jobs[job.NormalizedName] = rj
+				failedSteps[job.NormalizedName] = map[string]int{}
+			}
+			rj.Failures++
+			rj.LostTime += d
+			for _, step := range job.Job.Steps {
+				if isFailure(step.GetConclusion()) {
+					failedSteps[job.NormalizedName][step.GetName()]++
+				}
+			}
+		}
+	}
+	for name, rj := range jobs {
+		for stepName, count := range failedSteps[name] {
+			rj.FailedSteps = append(rj.FailedSteps, &FailedStep{Name: stepName, Count: count})
+		}
+		sort.Slice(rj.FailedSteps, func(i, j int) bool {
+			if rj.FailedSteps[i].Count != rj.FailedSteps[j].Count {
+				return rj.FailedSteps[i].Count > rj.FailedSteps[j].Count
+			}
+			return rj.FailedSteps[i].Name < rj.FailedSteps[j].Name
+		})
+		retries.Jobs = append(retries.Jobs, rj)
+	}
+	sort.Slice(retries.Jobs, func(i, j int) bool {
+		a, b := retries.Jobs[i], retries.Jobs[j]
+		if a.Failures != b.Failures {
+			return a.Failures > b.Failures
+		}
+		if a.LostTime != b.LostTime {
+			return a.LostTime > b.LostTime
+		}
+		return a.Name < b.Name
+	})
+	return retries
+}
+
+func (v *Viewer) ShowAttempts(runs, attempts []*collector.WorkflowRun) {
+	retries := getRetries(runs, attempts)
+	fmt.Fprintln(v.stdout, "## Retries")
+	fmt.Fprintln(v.stdout, "<table>")
+	fmt.Fprintf(v.stdout, "<tr><td>Re-run Workflow Runs</td><td>%d/%d</td></tr>\n", retries.RetriedRuns, retries.Runs)
+	fmt.Fprintf(v.stdout, "<tr><td>Previous Attempts</td><td>%d</td></tr>\n", retries.Attempts)
+	fmt.Fprintf(v.stdout, "<tr><td>Runner Time of Previous Attempts</td><td>%s (%.1f%% of %s)</td></tr>\n", retries.LostTime.Round(time.Second), retries.LostRate()*100, retries.TotalTime.Round(time.Second)) //nolint:mnd
+	fmt.Fprintf(v.stdout, "</table>\n\n")
+	if len(retries.Jobs) == 0 {
+		fmt.Fprintln(v.stdout, "There is no failed job in previous attempts")
+		return
+	}
+	fmt.Fprintln(v.stdout, "### Failed jobs in previous attempts")
+	for i, rj := range retries.Jobs {
+		fmt.Fprintf(v.stdout, "%d. %d failures (%s): %s\n", i+1, rj.Failures, rj.LostTime.Round(time.Second), rj.Name)
+		for _, step := range rj.FailedSteps {
+			fmt.Fprintf(v.stdout, "    - %d failures: %s\n", step.Count, step.Name)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package view
+
+import (
+	"testing"
+	"time"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+)
+
+func TestGetRetries(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	str := func(s string) *string { return &s }
+	newJob := func(id int64, name, conclusion string, d time.Duration) *collector.Job {
+		return &collector.Job{
+			Job: &github.WorkflowJob{
+				ID:          &id,
+				Name:        str(name),
+				Status:      str("completed"),
+				Conclusion:  str(conclusion),
+				StartedAt:   &github.Timestamp{Time: start},
+				CompletedAt: &github.Timestamp{Time: start.Add(d)},
+				Steps: []*github.TaskStep{
+					{Name: str("go test"), Conclusion: str(conclusion)},
+				},
+			},
+			NormalizedName: name,
+		}
+	}
+	newRun := func(attempt int, jobs ...*collector.Job) *collector.WorkflowRun {
+		return &collector.WorkflowRun{
+			Run:  &github.WorkflowRun{RunAttempt: &attempt},
+			Jobs: jobs,
+		}
+	}
+	tests := []struct {
+		name      string
+		runs      []*collector.WorkflowRun
+		attempts  []*collector.WorkflowRun
+		retried   int
+		lostTime  time.Duration
+		totalTime time.Duration
+		failures  map[string]int
+	}{
+		{
+			name:      "no retry",
+			runs:      []*collector.WorkflowRun{newRun(1, newJob(1, "build", "success", time.Minute))},
+			totalTime: time.Minute,
+			failures:  map[string]int{},
+		},
+		{
+			name: "re-run all jobs",
+			runs: []*collector.WorkflowRun{newRun(2, newJob(3, "build", "success", time.Minute), newJob(4, "test", "success", 2*time.Minute))},
+			attempts: []*collector.WorkflowRun{
+				newRun(1, newJob(1, "build", "success", time.Minute), newJob(2, "test", "failure", 3*time.Minute)),
+			},
+			retried:   1,
+			lostTime:  4 * time.Minute,
+			totalTime: 7 * time.Minute,
+			failures:  map[string]int{"test": 1},
+		},
+		{
+			// the successful job is carried over to the new attempt with the same job ID
+			name: "re-run failed jobs",
+			runs: []*collector.WorkflowRun{newRun(2, newJob(1, "build", "success", time.Minute), newJob(4, "test", "success", 2*time.Minute))},
+			attempts: []*collector.WorkflowRun{
+				newRun(1, newJob(1, "build", "success", time.Minute), newJob(2, "test", "failure", 3*time.Minute)),
+			},
+			retried:   1,
+			lostTime:  3 * time.Minute,
+			totalTime: 6 * time.Minute,
+			failures:  map[string]int{"test": 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			retries := getRetries(tt.runs, tt.attempts)
+			if retries.RetriedRuns != tt.retried {
+				t.Errorf("RetriedRuns = %d, want %d", retries.RetriedRuns, tt.retried)
+			}
+			if retries.LostTime != tt.lostTime || retries.TotalTime != tt.totalTime {
+				t.Errorf("LostTime/TotalTime = %s/%s, want %s/%s", retries.LostTime, retries.TotalTime, tt.lostTime, tt.totalTime)
+			}
+			if len(retries.Jobs) != len(tt.failures) {
+				t.Fatalf("the number of retried jobs = %d, want %d", len(retries.Jobs), len(tt.failures))
+			}
+			for _, rj := range retries.Jobs {
+				if rj.Failures != tt.failures[rj.Name] {
+					t.Errorf("failures of %s = %d, want %d", rj.Name, rj.Failures, tt.failures[rj.Name])
+				}
+				if len(rj.FailedSteps) != 1 || rj.FailedSteps[0].Name != "go test" {
+					t.Errorf("failed steps of %s = %v, want go test", rj.Name, rj.FailedSteps)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package view
+
+import "time"
+
+// Bar is a bar of a timeline chart.
+// Offset and Width are percentages of the whole timeline.
+type Bar struct {
+	Offset float64
+	Width  float64
+}
+
+func NewBar(start, end, timelineStart time.Time, timelineDuration time.Duration) *Bar {
+	if timelineDuration <= 0 || start.IsZero() || end.Before(start) {
+		return &Bar{}
+	}
+	// Log groups may start slightly before or end slightly after the step, so the bar is clamped to the timeline.
+	offset := max(float64(start.Sub(timelineStart))/float64(timelineDuration)*100, 0) //nolint:mnd
+	return &Bar{
+		Offset: offset,
+		Width:  min(float64(end.Sub(start))/float64(timelineDuration)*100, 100-offset), //nolint:mnd
+	}
+}
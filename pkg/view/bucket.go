@@ -0,0 +1,37 @@
+package view
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	BucketDay  = "day"
+	BucketWeek = "week"
+)
+
+// BucketStart returns the start time of the day or week (Monday) to which t belongs in UTC.
+func BucketStart(t time.Time, bucket string) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if bucket != BucketWeek {
+		return day
+	}
+	// time.Sunday is 0, so Sunday is moved to the end of the week
+	offset := (int(day.Weekday()) + 6) % 7 //nolint:mnd
+	return day.AddDate(0, 0, -offset)
+}
+
+// Percentile returns the p-th percentile (0 < p <= 100) of durations by the nearest-rank method.
+func Percentile(durations []time.Duration, p float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	rank := int(p/100*float64(len(sorted))+0.999999) - 1 //nolint:mnd
+	return sorted[min(max(rank, 0), len(sorted)-1)]
+}
@@ -0,0 +1,66 @@
+package view
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBucketStart(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		t      time.Time
+		bucket string
+		exp    time.Time
+	}{
+		{
+			name:   "day",
+			t:      time.Date(2025, 10, 29, 13, 56, 22, 0, time.UTC),
+			bucket: BucketDay,
+			exp:    time.Date(2025, 10, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "week",
+			t:      time.Date(2025, 10, 29, 13, 56, 22, 0, time.UTC), // Wednesday
+			bucket: BucketWeek,
+			exp:    time.Date(2025, 10, 27, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "sunday",
+			t:      time.Date(2025, 11, 2, 23, 0, 0, 0, time.UTC),
+			bucket: BucketWeek,
+			exp:    time.Date(2025, 10, 27, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if start := BucketStart(tt.t, tt.bucket); !start.Equal(tt.exp) {
+				t.Errorf("BucketStart() = %v, want %v", start, tt.exp)
+			}
+		})
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	t.Parallel()
+	durations := []time.Duration{5, 1, 4, 2, 3, 10, 6, 7, 9, 8}
+	tests := []struct {
+		name string
+		p    float64
+		exp  time.Duration
+	}{
+		{name: "p50", p: 50, exp: 5},
+		{name: "p90", p: 90, exp: 9},
+		{name: "p100", p: 100, exp: 10},
+		{name: "p1", p: 1, exp: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if d := Percentile(durations, tt.p); d != tt.exp {
+				t.Errorf("Percentile() = %v, want %v", d, tt.exp)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package view
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/cache"
+)
+
+func (v *Viewer) ShowCacheStats(dir string, stats []*cache.Stat) {
+	fmt.Fprintf(v.stdout, "## Cache: %s\n", dir)
+	if len(stats) == 0 {
+		fmt.Fprintln(v.stdout, "No cache is found")
+		return
+	}
+	var total cache.Stat
+	fmt.Fprintln(v.stdout, "Repository | Workflow Runs | Jobs | Metadata | Logs | Total")
+	fmt.Fprintln(v.stdout, "---|---|---|---|---|---")
+	for _, s := range stats {
+		fmt.Fprintf(v.stdout, "%s | %d | %d | %s | %s | %s\n", s.Repo, s.Runs, s.Jobs, cache.FormatSize(s.MetadataSize), cache.FormatSize(s.LogSize), cache.FormatSize(s.Size()))
+		total.Runs += s.Runs
+		total.Jobs += s.Jobs
+		total.MetadataSize += s.MetadataSize
+		total.LogSize += s.LogSize
+	}
+	if len(stats) > 1 {
+		fmt.Fprintf(v.stdout, "Total | %d | %d | %s | %s | %s\n", total.Runs, total.Jobs, cache.FormatSize(total.MetadataSize), cache.FormatSize(total.LogSize), cache.FormatSize(total.Size()))
+	}
+}
+
+func (v *Viewer) ShowPrunedCache(entries []*cache.Entry, dryRun bool) {
+	var size int64
+	for _, entry := range entries {
+		size += entry.Size
+		fmt.Fprintf(v.stdout, "- %s %s %s (%s, updated at %s)\n", entry.Repo, entry.Kind, entry.Name, cache.FormatSize(entry.Size), entry.ModTime.Format(time.RFC3339))
+	}
+	verb := "Removed"
+	if dryRun {
+		verb = "Would remove"
+	}
+	fmt.Fprintf(v.stdout, "%s %d entries (%s)\n", verb, len(entries), cache.FormatSize(size))
+}
+
+func (v *Viewer) ShowCacheIssues(issues []*cache.Issue, fixed bool) {
+	if len(issues) == 0 {
+		fmt.Fprintln(v.stdout, "No broken cache is found")
+		return
+	}
+	for _, issue := range issues {
+		fmt.Fprintf(v.stdout, "- %s: %s\n", issue.Path, issue.Message)
+	}
+	if fixed {
+		fmt.Fprintf(v.stdout, "Removed %d broken cache entries\n", len(issues))
+		return
+	}
+	fmt.Fprintf(v.stdout, "Found %d broken cache entries. Run ghaperf cache verify --fix to remove them\n", len(issues))
+}
@@ -0,0 +1,237 @@
+package view
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+)
+
+const (
+	// both segments must have this number of workflow runs at least so that a shift is lasting
+	minSegmentSize = 5
+	// the minimum t-statistic of significant shifts
+	minTStat = 4
+	// the minimum rate of significant shifts
+	minShiftRate = 0.1
+)
+
+type ChangePoint struct {
+	JobName  string
+	StepName string
+	Before   time.Duration
+	After    time.Duration
+	TStat    float64
+	// Run is the first workflow run after the shift
+	Run *github.WorkflowRun
+	// PullRequests are numbers of pull requests associated with Run.
+	// pull_requests of workflow runs are empty for push events on the default branch, so the caller can resolve them from the head commit.
+	PullRequests []int
+}
+
+func (cp *ChangePoint) Name() string {
+	if cp.StepName == "" {
+		return cp.JobName
+	}
+	return cp.JobName + " > " + cp.StepName
+}
+
+func (cp *ChangePoint) Shift() time.Duration {
+	return cp.After - cp.Before
+}
+
+// CommitMessage returns the first line of the head commit message.
+func (cp *ChangePoint) CommitMessage() string {
+	msg, _, _ := strings.Cut(cp.Run.GetHeadCommit().GetMessage(), "\n")
+	return msg
+}
+
+func (cp *ChangePoint) CommitURL() string {
+	return fmt.Sprintf("%s/commit/%s", cp.Run.GetRepository().GetHTMLURL(), cp.Run.GetHeadSHA())
+}
+
+// point is a sample of a time series.
+// Samples of a workflow run are merged to one point.
+type point struct {
+	run      *github.WorkflowRun
+	duration time.Duration
+}
+
+// GetChangePoints finds the most significant shift of each slow job and step.
+// Runs must be on the same branch.
+func GetChangePoints(runs []*collector.WorkflowRun, threshold time.Duration) []*ChangePoint {
+	sorted := make([]*collector.WorkflowRun, len(runs))
+	copy(sorted, runs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Run.GetCreatedAt().Before(sorted[j].Run.GetCreatedAt().Time)
+	})
+	jobs := map[string][]*point{}
+	steps := map[string]map[string][]*point{}
+	for _, run := range sorted {
+		setChangePointSeries(jobs, steps, run)
+	}
+	var cps []*ChangePoint
+	for jobName, series := range jobs {
+		if cp := findChangePoint(series, threshold); cp != nil {
+			cp.JobName = jobName
+			cps = append(cps, cp)
+		}
+		for stepName, series := range steps[jobName] {
+			if cp := findChangePoint(series, threshold); cp != nil {
+				cp.JobName = jobName
+				cp.StepName = stepName
+				cps = append(cps, cp)
+			}
+		}
+	}
+	sort.Slice(cps, func(i, j int) bool {
+		a, b := cps[i].Shift().Abs(), cps[j].Shift().Abs()
+		if a != b {
+			return a > b
+		}
+		return cps[i].Name() < cps[j].Name()
+	})
+	return cps
+}
+
+func setChangePointSeries(jobs map[string][]*point, steps map[string]map[string][]*point, run *collector.WorkflowRun) {
+	// Like ShowRuns, the slowest job and step are used for each name in a workflow run
+	jobDurations := map[string]time.Duration{}
+	stepDurations := map[string]map[string]time.Duration{}
+	for _, job := range run.Jobs {
+		if job.Job.GetStatus() != "completed" || job.Job.GetConclusion() == "skipped" {
+			continue
+		}
+		jobDurations[job.NormalizedName] = max(jobDurations[job.NormalizedName], job.Duration())
+		if _, ok := stepDurations[job.NormalizedName]; !ok {
+			stepDurations[job.NormalizedName] = map[string]time.Duration{}
+		}
+		for _, step := range JobSteps(job) {
+			stepDurations[job.NormalizedName][step.Name] = max(stepDurations[job.NormalizedName][step.Name], step.Duration())
+		}
+	}
+	for jobName, d := range jobDurations {
+		jobs[jobName] = append(jobs[jobName], &point{run: run.Run, duration: d})
+		if _, ok := steps[jobName]; !ok {
+			steps[jobName] = map[string][]*point{}
+		}
+		for stepName, d := range stepDurations[jobName] {
+			steps[jobName][stepName] = append(steps[jobName][stepName], &point{run: run.Run, duration: d})
+		}
+	}
+}
+
+// findChangePoint splits the series into two segments to maximize the Welch's t-statistic.
+// It returns nil if the shift isn't significant.
+func findChangePoint(series []*point, threshold time.Duration) *ChangePoint {
+	values := make([]float64, len(series))
+	for i, p := range series {
+		values[i] = float64(p.duration)
+	}
+	idx, t := splitSeries(values)
+	if idx < 0 || math.Abs(t) < minTStat {
+		return nil
+	}
+	before := time.Duration(mean(values[:idx]))
+	after := time.Duration(mean(values[idx:]))
+	if max(before, after) < threshold {
+		return nil
+	}
+	if before > 0 && math.Abs(float64(after-before))/float64(before) < minShiftRate {
+		return nil
+	}
+	run := series[idx].run
+	prs := make([]int, len(run.PullRequests))
+	for i, pr := range run.PullRequests {
+		prs[i] = pr.GetNumber()
+	}
+	return &ChangePoint{
+		Before:       before,
+		After:        after,
+		TStat:        t,
+		Run:          run,
+		PullRequests: prs,
+	}
+}
+
+// splitSeries returns the index of the first value after the split and the t-statistic.
+// It returns -1 if the series is too short.
+func splitSeries(values []float64) (int, float64) {
+	idx, tStat := -1, 0.0
+	for i := minSegmentSize; i <= len(values)-minSegmentSize; i++ {
+		t := welchT(values[:i], values[i:])
+		if idx < 0 || math.Abs(t) > math.Abs(tStat) {
+			idx, tStat = i, t
+		}
+	}
+	return idx, tStat
+}
+
+func welchT(a, b []float64) float64 {
+	ma, mb := mean(a), mean(b)
+	se := math.Sqrt(variance(a, ma)/float64(len(a)) + variance(b, mb)/float64(len(b)))
+	if se == 0 {
+		switch {
+		case mb > ma:
+			return math.Inf(1)
+		case mb < ma:
+			return math.Inf(-1)
+		default:
+			return 0
+		}
+	}
+	return (mb - ma) / se
+}
+
+func mean(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+func variance(values []float64, m float64) float64 {
+	if len(values) < 2 { //nolint:mnd
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += (v - m) * (v - m)
+	}
+	return sum / float64(len(values)-1)
+}
+
+func (v *Viewer) ShowChangePoints(cps []*ChangePoint) {
+	fmt.Fprintln(v.stdout, "## Change Points")
+	if len(cps) == 0 {
+		fmt.Fprintln(v.stdout, "There is no significant change")
+		return
+	}
+	for _, cp := range cps {
+		direction := "Slower"
+		if cp.Shift() < 0 {
+			direction = "Faster"
+		}
+		fmt.Fprintf(v.stdout, "### %s\n", cp.Name())
+		fmt.Fprintf(v.stdout, "%s by %s (%s → %s, t=%.1f) since [run %d](%s) at %s\n\n",
+			direction, cp.Shift().Abs().Round(time.Second), cp.Before.Round(time.Second), cp.After.Round(time.Second), cp.TStat,
+			cp.Run.GetRunNumber(), cp.Run.GetHTMLURL(), cp.Run.GetCreatedAt().Format(time.RFC3339))
+		fmt.Fprintf(v.stdout, "- Commit: [%s](%s) %s\n", shortSHA(cp.Run.GetHeadSHA()), cp.CommitURL(), cp.CommitMessage())
+		for _, number := range cp.PullRequests {
+			fmt.Fprintf(v.stdout, "- Pull Request: #%d\n", number)
+		}
+		fmt.Fprintln(v.stdout)
+	}
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 { //nolint:mnd
+		return sha[:7]
+	}
+	return sha
+}
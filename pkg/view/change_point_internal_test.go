@@ -0,0 +1,122 @@
+package view
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+)
+
+func TestSplitSeries(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		values []float64
+		idx    int
+		tStat  bool
+	}{
+		{
+			name:   "too short",
+			values: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9},
+			idx:    -1,
+		},
+		{
+			name:   "slower",
+			values: []float64{10, 11, 9, 10, 10, 11, 20, 21, 19, 20, 22, 20},
+			idx:    6,
+			tStat:  true,
+		},
+		{
+			name:   "stable",
+			values: []float64{10, 10, 10, 10, 10, 10, 10, 10, 10, 10},
+			idx:    5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			idx, tStat := splitSeries(tt.values)
+			if idx != tt.idx {
+				t.Errorf("splitSeries() index = %d, want %d", idx, tt.idx)
+			}
+			if tt.tStat != (tStat >= minTStat) {
+				t.Errorf("splitSeries() t-statistic = %f", tStat)
+			}
+		})
+	}
+}
+
+func newChangePointSeries(durations ...time.Duration) []*point {
+	series := make([]*point, len(durations))
+	for i, d := range durations {
+		number := i + 1
+		series[i] = &point{
+			run: &github.WorkflowRun{
+				RunNumber: &number,
+			},
+			duration: d,
+		}
+	}
+	return series
+}
+
+func TestFindChangePoint(t *testing.T) {
+	t.Parallel()
+	m := time.Minute
+	tests := []struct {
+		name      string
+		series    []*point
+		threshold time.Duration
+		exp       *ChangePoint
+	}{
+		{
+			name:   "too short",
+			series: newChangePointSeries(m, m, m, m, m, 2*m, 2*m, 2*m, 2*m),
+		},
+		{
+			name:   "flat",
+			series: newChangePointSeries(m, m, m, m, m, m, m, m, m, m),
+		},
+		{
+			name:   "step change",
+			series: newChangePointSeries(m, m, m, m, m, 3*m, 3*m, 3*m, 3*m, 3*m),
+			exp: &ChangePoint{
+				Before:       m,
+				After:        3 * m,
+				PullRequests: []int{},
+			},
+		},
+		{
+			name:      "faster than the threshold",
+			series:    newChangePointSeries(m, m, m, m, m, 3*m, 3*m, 3*m, 3*m, 3*m),
+			threshold: 5 * m,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cp := findChangePoint(tt.series, tt.threshold)
+			if tt.exp == nil {
+				if cp != nil {
+					t.Fatalf("findChangePoint() = %+v, want nil", cp)
+				}
+				return
+			}
+			if cp == nil {
+				t.Fatal("findChangePoint() = nil")
+			}
+			if cp.Run.GetRunNumber() != 6 {
+				t.Errorf("findChangePoint() run = %d, want 6", cp.Run.GetRunNumber())
+			}
+			if cp.TStat < minTStat {
+				t.Errorf("findChangePoint() t-statistic = %f", cp.TStat)
+			}
+			cp.Run = nil
+			cp.TStat = 0
+			if diff := cmp.Diff(tt.exp, cp); diff != "" {
+				t.Errorf("findChangePoint() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
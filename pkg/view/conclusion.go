@@ -0,0 +1,135 @@
+package view
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/config"
+)
+
+type JobConclusions struct {
+	Name        string
+	Conclusions []*ConclusionMetric
+}
+
+type ConclusionMetric struct {
+	Conclusion string
+	Metric     *Metric
+	Max        time.Duration
+	// TimeToFailure is the duration from the start of failed jobs to the end of the first failed step
+	TimeToFailure *Metric
+}
+
+// getJobConclusions aggregates durations of jobs by the normalized job name and the conclusion.
+// Unlike ShowRuns, each job is a sample even if jobs have the same normalized name in a workflow run.
+func getJobConclusions(runs []*collector.WorkflowRun, threshold time.Duration) []*JobConclusions {
+	jobs := map[string]map[string]*ConclusionMetric{}
+	for _, run := range runs {
+		for _, job := range run.Jobs {
+			if job.Job.GetStatus() != "completed" || job.Job.GetConclusion() == "skipped" {
+				continue
+			}
+			setConclusionMetric(jobs, job)
+		}
+	}
+	arr := make([]*JobConclusions, 0, len(jobs))
+	for name, cms := range jobs {
+		jc := &JobConclusions{Name: name}
+		slow := false
+		for _, cm := range cms {
+			if cm.Metric.Avg >= threshold {
+				slow = true
+			}
+			jc.Conclusions = append(jc.Conclusions, cm)
+		}
+		if !slow {
+			continue
+		}
+		sort.Slice(jc.Conclusions, func(i, j int) bool {
+			return jc.Conclusions[i].Metric.Count > jc.Conclusions[j].Metric.Count
+		})
+		arr = append(arr, jc)
+	}
+	sort.Slice(arr, func(i, j int) bool {
+		return arr[i].Name < arr[j].Name
+	})
+	return arr
+}
+
+func setConclusionMetric(jobs map[string]map[string]*ConclusionMetric, job *collector.Job) {
+	cms, ok := jobs[job.NormalizedName]
+	if !ok {
+		cms = map[string]*ConclusionMetric{}
+		jobs[job.NormalizedName] = cms
+	}
+	conclusion := job.Job.GetConclusion()
+	cm, ok := cms[conclusion]
+	if !ok {
+		cm = &ConclusionMetric{
+			Conclusion: conclusion,
+			Metric:     &Metric{},
+		}
+		cms[conclusion] = cm
+	}
+	d := job.Duration()
+	cm.Metric.Add(d)
+	cm.Max = max(cm.Max, d)
+	if conclusion != "failure" {
+		return
+	}
+	if cm.TimeToFailure == nil {
+		cm.TimeToFailure = &Metric{}
+	}
+	cm.TimeToFailure.Add(timeToFailure(job))
+}
+
+// timeToFailure returns the duration from the start of the job to the end of the first failed step.
+// If there is no failed step, the job duration is returned.
+func timeToFailure(job *collector.Job) time.Duration {
+	for _, step := range job.Job.Steps {
+		if step.GetConclusion() == "failure" {
+			return step.GetCompletedAt().Sub(job.Job.GetStartedAt().Time)
+		}
+	}
+	return job.Duration()
+}
+
+func (v *Viewer) ShowConclusions(runs []*collector.WorkflowRun, threshold time.Duration) {
+	jobs := getJobConclusions(runs, threshold)
+	fmt.Fprintln(v.stdout, "## Duration by Conclusion")
+	if len(jobs) == 0 {
+		fmt.Fprintln(v.stdout, "There is no slow job")
+		return
+	}
+	fmt.Fprintln(v.stdout, "Job | Conclusion | Count | Average | Max | Time to Failure")
+	fmt.Fprintln(v.stdout, "--- | --- | --- | --- | --- | ---")
+	for _, jc := range jobs {
+		for _, cm := range jc.Conclusions {
+			ttf := ""
+			if cm.TimeToFailure != nil {
+				ttf = cm.TimeToFailure.Avg.Round(time.Second).String()
+			}
+			fmt.Fprintf(v.stdout, "%s | %s | %d | %s | %s | %s\n", jc.Name, cm.Conclusion, cm.Metric.Count, cm.Metric.Avg.Round(time.Second), cm.Max.Round(time.Second), ttf)
+		}
+	}
+}
+
+// FilterJobConclusions returns workflow runs excluding jobs filtered by the job conclusion.
+// Jobs in progress are kept to list them.
+func FilterJobConclusions(runs []*collector.WorkflowRun, cfg *config.Config) []*collector.WorkflowRun {
+	if cfg == nil || len(cfg.JobConclusions) == 0 {
+		return runs
+	}
+	arr := make([]*collector.WorkflowRun, len(runs))
+	for i, run := range runs {
+		filtered := *run
+		filtered.Jobs = slices.DeleteFunc(slices.Clone(run.Jobs), func(job *collector.Job) bool {
+			return !job.Partial && !cfg.IncludeJobConclusion(job.Job.GetConclusion())
+		})
+		arr[i] = &filtered
+	}
+	return arr
+}
@@ -0,0 +1,93 @@
+package view
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/config"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+)
+
+func TestTimeToFailure(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	ts := func(d time.Duration) *github.Timestamp {
+		return &github.Timestamp{Time: start.Add(d)}
+	}
+	str := func(s string) *string { return &s }
+	newJob := func(steps ...*github.TaskStep) *collector.Job {
+		return &collector.Job{
+			Job: &github.WorkflowJob{
+				Status:      str("completed"),
+				Conclusion:  str("failure"),
+				StartedAt:   ts(0),
+				CompletedAt: ts(10 * time.Minute),
+				Steps:       steps,
+			},
+		}
+	}
+	tests := []struct {
+		name string
+		job  *collector.Job
+		want time.Duration
+	}{
+		{
+			name: "the first failed step",
+			job: newJob(
+				&github.TaskStep{Conclusion: str("success"), CompletedAt: ts(time.Minute)},
+				&github.TaskStep{Conclusion: str("failure"), CompletedAt: ts(3 * time.Minute)},
+				&github.TaskStep{Conclusion: str("failure"), CompletedAt: ts(9 * time.Minute)},
+			),
+			want: 3 * time.Minute,
+		},
+		{
+			name: "no failed step",
+			job:  newJob(&github.TaskStep{Conclusion: str("success"), CompletedAt: ts(time.Minute)}),
+			want: 10 * time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if d := timeToFailure(tt.job); d != tt.want {
+				t.Errorf("timeToFailure() = %s, want %s", d, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterJobConclusions(t *testing.T) {
+	t.Parallel()
+	str := func(s string) *string { return &s }
+	newJob := func(name, status, conclusion string) *collector.Job {
+		return &collector.Job{
+			Job:     &github.WorkflowJob{Name: str(name), Status: str(status), Conclusion: str(conclusion)},
+			Partial: status != "completed",
+		}
+	}
+	runs := []*collector.WorkflowRun{
+		{Jobs: []*collector.Job{
+			newJob("build", "completed", "success"),
+			newJob("test", "completed", "failure"),
+			newJob("deploy", "in_progress", ""),
+		}},
+	}
+	names := func(runs []*collector.WorkflowRun) []string {
+		var arr []string
+		for _, job := range runs[0].Jobs {
+			arr = append(arr, job.Job.GetName())
+		}
+		return arr
+	}
+	if diff := cmp.Diff([]string{"build", "test", "deploy"}, names(FilterJobConclusions(runs, &config.Config{}))); diff != "" {
+		t.Errorf("jobs shouldn't be filtered without the filter: %s", diff)
+	}
+	if diff := cmp.Diff([]string{"build", "deploy"}, names(FilterJobConclusions(runs, &config.Config{JobConclusions: []string{"success"}}))); diff != "" {
+		t.Errorf("jobs should be filtered by the conclusion: %s", diff)
+	}
+	if diff := cmp.Diff([]string{"build", "test", "deploy"}, names(runs)); diff != "" {
+		t.Errorf("the original workflow runs shouldn't be changed: %s", diff)
+	}
+}
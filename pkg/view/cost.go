@@ -0,0 +1,232 @@
+package view
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/config"
+)
+
+const daysPerMonth = 30
+
+type CostMetric struct {
+	Sum   float64
+	Count int
+	Avg   float64
+}
+
+func (m *CostMetric) Add(cost float64) {
+	m.Sum += cost
+	m.Count++
+	m.Avg = m.Sum / float64(m.Count)
+}
+
+type JobCost struct {
+	Name   string
+	Labels []string
+	Cost   *CostMetric
+	Steps  map[string]*StepCost
+}
+
+type StepCost struct {
+	JobName  string
+	Name     string
+	Duration *Metric
+	Cost     *CostMetric
+}
+
+type Cost struct {
+	Total         float64
+	Runs          int
+	RunsPerMonth  float64
+	Jobs          map[string]*JobCost
+	UnknownLabels map[string]struct{}
+}
+
+// jobCost returns the cost of a job.
+// GitHub bills hosted runners per minute, and rounds up each job's duration to the nearest minute.
+func jobCost(d time.Duration, rate float64) float64 {
+	return math.Ceil(d.Minutes()) * rate
+}
+
+func getCost(runs []*collector.WorkflowRun, cfg *config.Config) *Cost {
+	cost := &Cost{
+		Runs:          len(runs),
+		Jobs:          map[string]*JobCost{},
+		UnknownLabels: map[string]struct{}{},
+	}
+	for _, run := range runs {
+		for _, job := range run.Jobs {
+			setJobCost(cost, cfg, job)
+		}
+	}
+	cost.RunsPerMonth = getRunsPerMonth(runs)
+	return cost
+}
+
+func setJobCost(cost *Cost, cfg *config.Config, job *collector.Job) {
+	if job.Job.GetStatus() != "completed" {
+		return
+	}
+	if job.Job.GetConclusion() == "skipped" {
+		return
+	}
+	rate, ok := cfg.RunnerCost(job.Job.Labels)
+	if !ok {
+		cost.UnknownLabels[strings.Join(job.Job.Labels, ",")] = struct{}{}
+		return
+	}
+	jc, ok := cost.Jobs[job.NormalizedName]
+	if !ok {
+		jc = &JobCost{
+			Name:   job.NormalizedName,
+			Labels: job.Job.Labels,
+			Cost:   &CostMetric{},
+			Steps:  map[string]*StepCost{},
+		}
+		cost.Jobs[job.NormalizedName] = jc
+	}
+	c := jobCost(job.Duration(), rate)
+	jc.Cost.Add(c)
+	cost.Total += c
+	for _, s := range job.Job.Steps {
+		d, ok := collector.StepDuration(s)
+		if !ok {
+			continue
+		}
+		sc, ok := jc.Steps[s.GetName()]
+		if !ok {
+			sc = &StepCost{
+				JobName:  job.NormalizedName,
+				Name:     s.GetName(),
+				Duration: &Metric{},
+				Cost:     &CostMetric{},
+			}
+			jc.Steps[s.GetName()] = sc
+		}
+		sc.Duration.Add(d)
+		// Steps aren't billed separately, so the step cost isn't rounded up
+		sc.Cost.Add(d.Minutes() * rate)
+	}
+}
+
+// minRunsSpan is the minimum span of workflow runs to estimate the frequency.
+// Without it, a few workflow runs created at almost the same time would be estimated as millions of runs per month.
+const minRunsSpan = 24 * time.Hour
+
+// getRunsPerMonth estimates the number of workflow runs per month from the creation time of runs.
+// N workflow runs have N-1 intervals between the oldest and the newest ones.
+// It returns 0 if the frequency can't be estimated.
+func getRunsPerMonth(runs []*collector.WorkflowRun) float64 {
+	if len(runs) < 2 { //nolint:mnd
+		return 0
+	}
+	var oldest, newest time.Time
+	for _, run := range runs {
+		createdAt := run.Run.GetCreatedAt().Time
+		if oldest.IsZero() || createdAt.Before(oldest) {
+			oldest = createdAt
+		}
+		if createdAt.After(newest) {
+			newest = createdAt
+		}
+	}
+	span := max(newest.Sub(oldest), minRunsSpan)
+	return float64(len(runs)-1) / span.Hours() * 24 * daysPerMonth //nolint:mnd
+}
+
+func formatCost(c float64) string {
+	if c > 0 && c < 0.01 {
+		return fmt.Sprintf("$%.4f", c)
+	}
+	return fmt.Sprintf("$%.2f", c)
+}
+
+func (v *Viewer) ShowCost(runs []*collector.WorkflowRun, threshold time.Duration, cfg *config.Config) {
+	cost := getCost(runs, cfg)
+	fmt.Fprintln(v.stdout, "## Estimated Cost")
+	fmt.Fprintln(v.stdout, "<table>")
+	fmt.Fprintf(v.stdout, "<tr><td>Total Cost</td><td>%s (%d runs)</td></tr>\n", formatCost(cost.Total), cost.Runs)
+	if cost.Runs > 0 {
+		fmt.Fprintf(v.stdout, "<tr><td>Average Cost per Workflow Run</td><td>%s</td></tr>\n", formatCost(cost.Total/float64(cost.Runs)))
+	}
+	if cost.RunsPerMonth > 0 {
+		fmt.Fprintf(v.stdout, "<tr><td>Workflow Runs per Month</td><td>%.0f</td></tr>\n", cost.RunsPerMonth)
+		fmt.Fprintf(v.stdout, "<tr><td>Estimated Monthly Cost</td><td>%s</td></tr>\n", formatCost(cost.Total/float64(cost.Runs)*cost.RunsPerMonth))
+	}
+	if len(cost.UnknownLabels) > 0 {
+		labels := slices.Sorted(maps.Keys(cost.UnknownLabels))
+		fmt.Fprintf(v.stdout, "<tr><td>Runners without rates</td><td>%s</td></tr>\n", strings.Join(labels, ", "))
+	}
+	fmt.Fprintf(v.stdout, "</table>\n\n")
+
+	jobs := cost.sortedJobs()
+	if len(jobs) == 0 {
+		return
+	}
+	monthlyRatio := cost.monthlyRatio()
+	fmt.Fprintln(v.stdout, "### Cost per job")
+	for i, jc := range jobs {
+		if monthlyRatio > 0 {
+			fmt.Fprintf(v.stdout, "%d. %s (%s/%d, %s/month): %s (%s)\n", i+1, formatCost(jc.Cost.Avg), formatCost(jc.Cost.Sum), jc.Cost.Count, formatCost(jc.Cost.Sum*monthlyRatio), jc.Name, strings.Join(jc.Labels, ", "))
+			continue
+		}
+		fmt.Fprintf(v.stdout, "%d. %s (%s/%d): %s (%s)\n", i+1, formatCost(jc.Cost.Avg), formatCost(jc.Cost.Sum), jc.Cost.Count, jc.Name, strings.Join(jc.Labels, ", "))
+	}
+	v.showStepCosts(jobs, threshold, monthlyRatio)
+}
+
+func (c *Cost) sortedJobs() []*JobCost {
+	jobs := slices.Collect(maps.Values(c.Jobs))
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].Cost.Sum > jobs[j].Cost.Sum
+	})
+	return jobs
+}
+
+// monthlyRatio converts the cost over the analyzed workflow runs to the monthly cost.
+// It returns 0 if the frequency of workflow runs is unknown.
+func (c *Cost) monthlyRatio() float64 {
+	if c.Runs == 0 {
+		return 0
+	}
+	return c.RunsPerMonth / float64(c.Runs)
+}
+
+func getSlowStepCosts(jobs []*JobCost, threshold time.Duration) []*StepCost {
+	steps := []*StepCost{}
+	for _, jc := range jobs {
+		for _, sc := range jc.Steps {
+			if sc.Duration.Avg < threshold {
+				continue
+			}
+			steps = append(steps, sc)
+		}
+	}
+	sort.Slice(steps, func(i, j int) bool {
+		return steps[i].Cost.Sum > steps[j].Cost.Sum
+	})
+	return steps
+}
+
+// showStepCosts shows the cost of slow steps.
+func (v *Viewer) showStepCosts(jobs []*JobCost, threshold time.Duration, monthlyRatio float64) {
+	steps := getSlowStepCosts(jobs, threshold)
+	if len(steps) == 0 {
+		return
+	}
+	fmt.Fprintln(v.stdout, "### Cost of slow steps")
+	for i, sc := range steps {
+		if monthlyRatio > 0 {
+			fmt.Fprintf(v.stdout, "%d. %s (%s/%d, %s/month): %s > %s\n", i+1, formatCost(sc.Cost.Avg), formatCost(sc.Cost.Sum), sc.Cost.Count, formatCost(sc.Cost.Sum*monthlyRatio), sc.JobName, sc.Name)
+			continue
+		}
+		fmt.Fprintf(v.stdout, "%d. %s (%s/%d): %s > %s\n", i+1, formatCost(sc.Cost.Avg), formatCost(sc.Cost.Sum), sc.Cost.Count, sc.JobName, sc.Name)
+	}
+}
@@ -0,0 +1,75 @@
+package view
+
+import (
+	"testing"
+	"time"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+)
+
+func TestJobCost(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		d    time.Duration
+		want float64
+	}{
+		{name: "zero", d: 0, want: 0},
+		{name: "rounded up", d: time.Second, want: 0.008},
+		{name: "just a minute", d: time.Minute, want: 0.008},
+		{name: "over a minute", d: time.Minute + time.Second, want: 0.016},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := jobCost(tt.d, 0.008); got != tt.want {
+				t.Errorf("jobCost(%s) = %v, want %v", tt.d, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetRunsPerMonth(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	newRuns := func(intervals ...time.Duration) []*collector.WorkflowRun {
+		runs := make([]*collector.WorkflowRun, len(intervals))
+		createdAt := start
+		for i, d := range intervals {
+			createdAt = createdAt.Add(d)
+			runs[i] = &collector.WorkflowRun{
+				Run: &github.WorkflowRun{CreatedAt: &github.Timestamp{Time: createdAt}},
+			}
+		}
+		return runs
+	}
+	tests := []struct {
+		name string
+		runs []*collector.WorkflowRun
+		want float64
+	}{
+		{
+			name: "a run",
+			runs: newRuns(0),
+		},
+		{
+			name: "a run per day",
+			runs: newRuns(0, 24*time.Hour, 24*time.Hour, 24*time.Hour),
+			want: 30,
+		},
+		{
+			name: "runs at almost the same time",
+			runs: newRuns(0, 5*time.Second),
+			want: 30,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := getRunsPerMonth(tt.runs); got != tt.want {
+				t.Errorf("getRunsPerMonth() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package view
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/config"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/parser"
+)
+
+var csvHeader = []string{ //nolint:gochecknoglobals
+	"kind",
+	"run_id",
+	"run_attempt",
+	"workflow_name",
+	"branch",
+	"job_id",
+	"job_name",
+	"normalized_job_name",
+	"runner_name",
+	"conclusion",
+	"step_number",
+	"step_name",
+	"group_name",
+	"started_at",
+	"completed_at",
+	"duration_seconds",
+}
+
+// CSVViewer exports raw samples of jobs, steps, and log groups as CSV or TSV.
+// Unlike other viewers, it doesn't aggregate samples and ignores the threshold.
+// Reports which aren't based on samples such as the cost report are omitted.
+type CSVViewer struct {
+	writer *csv.Writer
+	err    error
+}
+
+func NewCSV(stdout io.Writer, comma rune) *CSVViewer {
+	w := csv.NewWriter(stdout)
+	w.Comma = comma
+	return &CSVViewer{
+		writer: w,
+	}
+}
+
+// Err returns the first error which occurred while writing samples.
+func (v *CSVViewer) Err() error {
+	return v.err
+}
+
+func (v *CSVViewer) write(samples []*Sample) {
+	if v.err != nil {
+		return
+	}
+	for _, sample := range samples {
+		if err := v.writer.Write(sampleRecord(sample)); err != nil {
+			v.err = fmt.Errorf("write a sample: %w", err)
+			return
+		}
+	}
+	v.writer.Flush()
+	if err := v.writer.Error(); err != nil {
+		v.err = fmt.Errorf("write samples: %w", err)
+	}
+}
+
+func sampleRecord(s *Sample) []string {
+	return []string{
+		s.Kind,
+		formatInt(s.RunID),
+		formatInt(s.RunAttempt),
+		s.WorkflowName,
+		s.Branch,
+		formatInt(s.JobID),
+		s.JobName,
+		s.NormalizedJobName,
+		s.RunnerName,
+		s.Conclusion,
+		formatInt(s.StepNumber),
+		s.StepName,
+		s.GroupName,
+		formatTimestamp(s.StartedAt),
+		formatTimestamp(s.CompletedAt),
+		strconv.FormatFloat(s.Duration.Seconds(), 'f', 3, 64), //nolint:mnd
+	}
+}
+
+func formatInt(i int64) string {
+	if i == 0 {
+		return ""
+	}
+	return strconv.FormatInt(i, 10)
+}
+
+func formatTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+func (v *CSVViewer) ShowHeader(_ *HeaderArg) {
+	if v.err != nil {
+		return
+	}
+	if err := v.writer.Write(csvHeader); err != nil {
+		v.err = fmt.Errorf("write a header: %w", err)
+	}
+}
+
+func (v *CSVViewer) ShowJob(job *collector.Job, _ time.Duration) {
+	v.write(listJobSamples(job))
+}
+
+func (v *CSVViewer) ShowGroups(groups []*parser.Group, _ time.Duration) {
+	v.write(listGroupSamples(groups))
+}
+
+func (v *CSVViewer) ShowLogGroups(jobs []*collector.Job, _ time.Duration) {
+	for _, job := range jobs {
+		samples := listGroupSamples(job.Groups)
+		for _, sample := range samples {
+			sample.JobName = job.Job.GetName()
+			sample.NormalizedJobName = job.NormalizedName
+		}
+		v.write(samples)
+	}
+}
+
+func (v *CSVViewer) ShowRun(run *collector.WorkflowRun, _ time.Duration) {
+	v.write(ListSamples(run))
+}
+
+func (v *CSVViewer) ShowRuns(runs []*collector.WorkflowRun, _ time.Duration) {
+	for _, run := range runs {
+		v.write(ListSamples(run))
+	}
+}
+
+func (v *CSVViewer) ShowActions(_ []*collector.WorkflowRun, _ time.Duration) {}
+
+func (v *CSVViewer) ShowCost(_ []*collector.WorkflowRun, _ time.Duration, _ *config.Config) {}
+
+func (v *CSVViewer) ShowTrend(_ []*collector.WorkflowRun, _ time.Duration, _, _ string) {}
+
+func (v *CSVViewer) ShowChangePoints(_ []*ChangePoint) {}
+
+func (v *CSVViewer) ShowConclusions(_ []*collector.WorkflowRun, _ time.Duration) {}
+
+func (v *CSVViewer) ShowHangs(_ []*collector.WorkflowRun, _ time.Duration) {}
+
+// ShowAttempts exports samples of previous attempts.
+// They can be distinguished from the latest attempts by the run_attempt column.
+func (v *CSVViewer) ShowAttempts(_, attempts []*collector.WorkflowRun) {
+	for _, run := range attempts {
+		v.write(ListSamples(run))
+	}
+}
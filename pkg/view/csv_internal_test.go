@@ -0,0 +1,57 @@
+package view
+
+import (
+	"bytes"
+	"encoding/csv"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCSVViewer(t *testing.T) {
+	t.Parallel()
+	for name, comma := range map[string]rune{"csv": ',', "tsv": '\t'} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			runs := newTestRuns()
+			buf := &bytes.Buffer{}
+			v := NewCSV(buf, comma)
+			v.ShowHeader(&HeaderArg{})
+			v.ShowRun(runs[0], time.Second)
+			v.ShowLogGroups(runs[1].Jobs, time.Second)
+			if err := v.Err(); err != nil {
+				t.Fatal(err)
+			}
+			r := csv.NewReader(buf)
+			r.Comma = comma
+			records, err := r.ReadAll()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(records) == 0 || !slices.Equal(records[0], csvHeader) {
+				t.Fatalf("the header = %v, want %v", records[0], csvHeader)
+			}
+			records = records[1:]
+			wantKinds := []string{"job", "step", "step", "group", "job", "group"}
+			wantSteps := []string{"", "Set up job", "Run make <test>", "Run make <test>", "", ""}
+			wantJobs := []string{testJobName, testJobName, testJobName, testJobName, "lint", testJobName}
+			if len(records) != len(wantKinds) {
+				t.Fatalf("the number of rows = %d, want %d: %v", len(records), len(wantKinds), records)
+			}
+			for i, record := range records {
+				if len(record) != len(csvHeader) {
+					t.Fatalf("row %d has %d columns, want %d", i, len(record), len(csvHeader))
+				}
+				if record[0] != wantKinds[i] || record[6] != wantJobs[i] || record[11] != wantSteps[i] {
+					t.Errorf("row %d = kind %s, job %q, step %q, want kind %s, job %q, step %q", i, record[0], record[6], record[11], wantKinds[i], wantJobs[i], wantSteps[i])
+				}
+			}
+			if job := records[0]; job[5] != "1" || job[9] != "success" || job[15] != "600.000" {
+				t.Errorf("the job row = %v, want job_id 1, conclusion success, and 600 seconds", job)
+			}
+			if group := records[3]; group[12] != "Run make <test>" || group[13] != "2025-10-01T00:01:00Z" {
+				t.Errorf("the group row = %v", group)
+			}
+		})
+	}
+}
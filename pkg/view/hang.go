@@ -0,0 +1,231 @@
+package view
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/parser"
+)
+
+const (
+	HangReasonTimeout   = "timeout"
+	HangReasonCancelled = "cancelled"
+	// the number of log lines before the job stopped producing output
+	countLastLines = 5
+	// a marker of log lines written when jobs time out
+	timeoutMarker = "has exceeded the maximum execution time of"
+)
+
+// markers of log lines written when jobs are cancelled or time out
+var cancelMarkers = []string{ //nolint:gochecknoglobals
+	"The operation was canceled.",
+	timeoutMarker,
+}
+
+// Hang is a job which timed out or was cancelled.
+type Hang struct {
+	Job      *collector.Job
+	Reason   string
+	StepName string
+	// LastOutput is the time of the last log line before the job stopped
+	LastOutput time.Time
+	// StoppedAt is the time when the job was cancelled
+	StoppedAt time.Time
+	LastLines []string
+}
+
+// Silence returns the duration while the job produced no output before it stopped.
+func (h *Hang) Silence() time.Duration {
+	if h.LastOutput.IsZero() {
+		return 0
+	}
+	return h.StoppedAt.Sub(h.LastOutput)
+}
+
+// HangGroup aggregates hangs by the normalized job name and the step.
+type HangGroup struct {
+	JobName  string
+	StepName string
+	Hangs    []*Hang
+	Timeouts int
+	Time     time.Duration
+	Silence  *Metric
+}
+
+func (hg *HangGroup) Name() string {
+	if hg.StepName == "" {
+		return hg.JobName
+	}
+	return hg.JobName + " > " + hg.StepName
+}
+
+// RecentHangs returns recent hangs up to countSlowest.
+func (hg *HangGroup) RecentHangs() []*Hang {
+	return hg.Hangs[:min(len(hg.Hangs), countSlowest)]
+}
+
+func getHangGroups(runs []*collector.WorkflowRun, threshold time.Duration) []*HangGroup {
+	groups := map[string]*HangGroup{}
+	for _, run := range runs {
+		for _, job := range run.Jobs {
+			hang := detectHang(job, threshold)
+			if hang == nil {
+				continue
+			}
+			key := job.NormalizedName + "\n" + hang.StepName
+			hg, ok := groups[key]
+			if !ok {
+				hg = &HangGroup{
+					JobName:  job.NormalizedName,
+					StepName: hang.StepName,
+					Silence:  &Metric{},
+				}
+				groups[key] = hg
+			}
+			hg.Hangs = append(hg.Hangs, hang)
+			hg.Time += job.Duration()
+			hg.Silence.Add(hang.Silence())
+			if hang.Reason == HangReasonTimeout {
+				hg.Timeouts++
+			}
+		}
+	}
+	arr := make([]*HangGroup, 0, len(groups))
+	for _, hg := range groups {
+		sort.Slice(hg.Hangs, func(i, j int) bool {
+			return hg.Hangs[i].StoppedAt.After(hg.Hangs[j].StoppedAt)
+		})
+		arr = append(arr, hg)
+	}
+	sort.Slice(arr, func(i, j int) bool {
+		if arr[i].Time != arr[j].Time {
+			return arr[i].Time > arr[j].Time
+		}
+		return arr[i].Name() < arr[j].Name()
+	})
+	return arr
+}
+
+// detectHang returns nil if the job didn't time out or wasn't cancelled after the threshold.
+func detectHang(job *collector.Job, threshold time.Duration) *Hang {
+	if job.Job.GetStatus() != "completed" || job.Duration() < threshold {
+		return nil
+	}
+	lines := jobLines(job.Groups)
+	markerIdx := -1
+	for i, line := range lines {
+		if isCancelMarker(line.Content) {
+			markerIdx = i
+			break
+		}
+	}
+	reason := ""
+	switch {
+	case job.Job.GetConclusion() == "timed_out":
+		reason = HangReasonTimeout
+	case markerIdx >= 0 && strings.Contains(lines[markerIdx].Content, timeoutMarker):
+		reason = HangReasonTimeout
+	case job.Job.GetConclusion() == "cancelled":
+		reason = HangReasonCancelled
+	default:
+		return nil
+	}
+	hang := &Hang{
+		Job:       job,
+		Reason:    reason,
+		StoppedAt: job.Job.GetCompletedAt().Time,
+	}
+	if markerIdx >= 0 {
+		hang.StoppedAt = lines[markerIdx].Timestamp
+		lines = lines[:markerIdx]
+	}
+	if len(lines) > 0 {
+		hang.LastOutput = lines[len(lines)-1].Timestamp
+		for _, line := range lines[max(len(lines)-countLastLines, 0):] {
+			hang.LastLines = append(hang.LastLines, line.Content)
+		}
+	}
+	hang.StepName = hangStep(job, hang.LastOutput)
+	return hang
+}
+
+func isCancelMarker(content string) bool {
+	for _, marker := range cancelMarkers {
+		if strings.Contains(content, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func jobLines(groups []*parser.Group) []*parser.Line {
+	var lines []*parser.Line
+	for _, group := range groups {
+		lines = append(lines, group.Lines...)
+	}
+	return lines
+}
+
+// hangStep returns the step which was running when the job stopped producing output.
+// If the log isn't available, the cancelled step is returned.
+func hangStep(job *collector.Job, lastOutput time.Time) string {
+	for _, step := range job.Job.Steps {
+		if lastOutput.IsZero() {
+			if step.GetConclusion() == "cancelled" || step.GetConclusion() == "failure" {
+				return step.GetName()
+			}
+			continue
+		}
+		if !step.GetStartedAt().After(lastOutput) && step.GetCompletedAt().After(lastOutput) {
+			return step.GetName()
+		}
+	}
+	return ""
+}
+
+func (v *Viewer) ShowHangs(runs []*collector.WorkflowRun, threshold time.Duration) {
+	groups := getHangGroups(runs, threshold)
+	fmt.Fprintln(v.stdout, "## Hangs and Timeouts")
+	if len(groups) == 0 {
+		fmt.Fprintln(v.stdout, "There is no job which timed out or was cancelled")
+		return
+	}
+	for i, hg := range groups {
+		fmt.Fprintf(v.stdout, "%d. %s: %d incidents (%d timeouts), %s runner time, silent for %s on average\n",
+			i+1, escapeTableCell(hg.Name()), len(hg.Hangs), hg.Timeouts, hg.Time.Round(time.Second), hg.Silence.Avg.Round(time.Second))
+		for _, hang := range hg.RecentHangs() {
+			fmt.Fprintf(v.stdout, "    - [%s](%s) at %s: %s after %s, silent for %s\n",
+				escapeTableCell(hang.Job.Job.GetName()), hang.Job.Job.GetHTMLURL(), hang.StoppedAt.Format(time.RFC3339),
+				hang.Reason, hang.Job.Duration().Round(time.Second), hang.Silence().Round(time.Second))
+			if len(hang.LastLines) == 0 {
+				continue
+			}
+			fence := codeFence(hang.LastLines)
+			fmt.Fprintf(v.stdout, "      %s\n", fence)
+			for _, line := range hang.LastLines {
+				fmt.Fprintf(v.stdout, "      %s\n", line)
+			}
+			fmt.Fprintf(v.stdout, "      %s\n", fence)
+		}
+	}
+}
+
+// codeFence returns a code fence longer than any run of backticks in lines so that lines can't close the code block.
+func codeFence(lines []string) string {
+	longest := 0
+	for _, line := range lines {
+		n := 0
+		for _, c := range line {
+			if c != '`' {
+				n = 0
+				continue
+			}
+			n++
+			longest = max(longest, n)
+		}
+	}
+	return strings.Repeat("`", max(longest+1, 3)) //nolint:mnd
+}
@@ -0,0 +1,176 @@
+package view
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/parser"
+)
+
+func TestDetectHang(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2025, 10, 29, 12, 0, 0, 0, time.UTC)
+	ts := func(d time.Duration) *github.Timestamp {
+		return &github.Timestamp{Time: start.Add(d)}
+	}
+	str := func(s string) *string { return &s }
+	newJob := func(conclusion string, lines []*parser.Line) *collector.Job {
+		return &collector.Job{
+			Job: &github.WorkflowJob{
+				Name:        str("test"),
+				Status:      str("completed"),
+				Conclusion:  str(conclusion),
+				StartedAt:   ts(0),
+				CompletedAt: ts(60 * time.Minute),
+				Steps: []*github.TaskStep{
+					{Name: str("checkout"), StartedAt: ts(0), CompletedAt: ts(time.Minute), Conclusion: str("success")},
+					{Name: str("go test"), StartedAt: ts(time.Minute), CompletedAt: ts(60 * time.Minute), Conclusion: str("cancelled")},
+				},
+			},
+			Groups:         []*parser.Group{{Lines: lines}},
+			NormalizedName: "test",
+		}
+	}
+	tests := []struct {
+		name     string
+		job      *collector.Job
+		isNil    bool
+		reason   string
+		step     string
+		silence  time.Duration
+		lastLine string
+	}{
+		{
+			name:  "success",
+			job:   newJob("success", nil),
+			isNil: true,
+		},
+		{
+			name: "timeout",
+			job: newJob("failure", []*parser.Line{
+				{Timestamp: ts(30 * time.Second).Time, Content: "checkout"},
+				{Timestamp: ts(2 * time.Minute).Time, Content: "=== RUN TestFoo"},
+				{Timestamp: ts(59 * time.Minute).Time, Content: "##[error]The job running on runner foo has exceeded the maximum execution time of 58 minutes."},
+				{Timestamp: ts(59 * time.Minute).Time, Content: "##[error]The operation was canceled."},
+			}),
+			reason:   HangReasonTimeout,
+			step:     "go test",
+			silence:  57 * time.Minute,
+			lastLine: "=== RUN TestFoo",
+		},
+		{
+			name:    "cancelled without log",
+			job:     newJob("cancelled", nil),
+			reason:  HangReasonCancelled,
+			step:    "go test",
+			silence: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			hang := detectHang(tt.job, 10*time.Minute)
+			if tt.isNil {
+				if hang != nil {
+					t.Fatalf("detectHang() = %+v, want nil", hang)
+				}
+				return
+			}
+			if hang == nil {
+				t.Fatal("detectHang() = nil")
+			}
+			if hang.Reason != tt.reason {
+				t.Errorf("Reason = %s, want %s", hang.Reason, tt.reason)
+			}
+			if hang.StepName != tt.step {
+				t.Errorf("StepName = %s, want %s", hang.StepName, tt.step)
+			}
+			if hang.Silence() != tt.silence {
+				t.Errorf("Silence() = %s, want %s", hang.Silence(), tt.silence)
+			}
+			if tt.lastLine != "" && hang.LastLines[len(hang.LastLines)-1] != tt.lastLine {
+				t.Errorf("the last line = %s, want %s", hang.LastLines[len(hang.LastLines)-1], tt.lastLine)
+			}
+		})
+	}
+}
+
+func TestCodeFence(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		lines []string
+		exp   string
+	}{
+		{
+			name:  "no backtick",
+			lines: []string{"=== RUN TestFoo"},
+			exp:   "```",
+		},
+		{
+			name:  "inline code",
+			lines: []string{"run `go test`"},
+			exp:   "```",
+		},
+		{
+			name:  "code fence",
+			lines: []string{"```sh", "echo foo", "````"},
+			exp:   "`````",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if fence := codeFence(tt.lines); fence != tt.exp {
+				t.Errorf("codeFence() = %s, want %s", fence, tt.exp)
+			}
+		})
+	}
+}
+
+func TestViewer_ShowHangs(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2025, 10, 29, 12, 0, 0, 0, time.UTC)
+	ts := func(d time.Duration) *github.Timestamp {
+		return &github.Timestamp{Time: start.Add(d)}
+	}
+	str := func(s string) *string { return &s }
+	runs := []*collector.WorkflowRun{
+		{
+			Jobs: []*collector.Job{
+				{
+					Job: &github.WorkflowJob{
+						Name:        str("test"),
+						Status:      str("completed"),
+						Conclusion:  str("cancelled"),
+						StartedAt:   ts(0),
+						CompletedAt: ts(60 * time.Minute),
+						Steps: []*github.TaskStep{
+							{Name: str("go test\n| tee"), StartedAt: ts(0), CompletedAt: ts(60 * time.Minute), Conclusion: str("cancelled")},
+						},
+					},
+					Groups: []*parser.Group{{Lines: []*parser.Line{
+						{Timestamp: ts(time.Minute).Time, Content: "```"},
+						{Timestamp: ts(59 * time.Minute).Time, Content: "##[error]The operation was canceled."},
+					}}},
+					NormalizedName: "test",
+				},
+			},
+		},
+	}
+	buf := &bytes.Buffer{}
+	New(buf).ShowHangs(runs, time.Minute)
+	out := buf.String()
+	for _, s := range []string{
+		"1. test > go test \\| tee: 1 incidents",
+		"      ````\n      ```\n      ````\n",
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("the output doesn't contain %q\n%s", s, out)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package view
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+type HistoryBucket struct {
+	Start time.Time
+	Count int
+	Avg   time.Duration
+	P50   time.Duration
+	P90   time.Duration
+}
+
+// AggregateSamples aggregates durations of samples by the day or week when samples started.
+func AggregateSamples(samples []*Sample, bucket string) []*HistoryBucket {
+	durations := map[time.Time][]time.Duration{}
+	for _, sample := range samples {
+		start := BucketStart(sample.StartedAt, bucket)
+		durations[start] = append(durations[start], sample.Duration)
+	}
+	buckets := make([]*HistoryBucket, 0, len(durations))
+	for start, ds := range durations {
+		var sum time.Duration
+		for _, d := range ds {
+			sum += d
+		}
+		buckets = append(buckets, &HistoryBucket{
+			Start: start,
+			Count: len(ds),
+			Avg:   sum / time.Duration(len(ds)),
+			P50:   Percentile(ds, 50), //nolint:mnd
+			P90:   Percentile(ds, 90), //nolint:mnd
+		})
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Start.Before(buckets[j].Start)
+	})
+	return buckets
+}
+
+func (v *Viewer) ShowHistory(title string, buckets []*HistoryBucket) {
+	fmt.Fprintf(v.stdout, "## History: %s\n", title)
+	if len(buckets) == 0 {
+		fmt.Fprintln(v.stdout, "No sample is found")
+		return
+	}
+	fmt.Fprintln(v.stdout, "Period | Count | Average | p50 | p90")
+	fmt.Fprintln(v.stdout, "---|---|---|---|---")
+	for _, b := range buckets {
+		fmt.Fprintf(v.stdout, "%s | %d | %s | %s | %s\n", b.Start.Format(time.DateOnly), b.Count, b.Avg.Round(time.Second), b.P50.Round(time.Second), b.P90.Round(time.Second))
+	}
+}
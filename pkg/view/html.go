@@ -0,0 +1,339 @@
+package view
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"io"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/config"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/parser"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+//go:embed html/report.html
+var htmlFS embed.FS
+
+//go:embed html/report.css
+var reportCSS string
+
+//go:embed html/report.js
+var reportJS string
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{ //nolint:gochecknoglobals
+	"duration": func(d time.Duration) string { return d.Round(time.Second).String() },
+	"time":     func(t time.Time) string { return t.Format(time.RFC3339) },
+	"cost":     formatCost,
+	"mul":      func(a, b float64) float64 { return a * b },
+	"percent": func(d, maxD time.Duration) float64 {
+		if maxD <= 0 {
+			return 0
+		}
+		return float64(d) / float64(maxD) * 100 //nolint:mnd
+	},
+	"css": func() template.CSS { return template.CSS(reportCSS) }, //nolint:gosec
+	"js":  func() template.JS { return template.JS(reportJS) },    //nolint:gosec
+}).ParseFS(htmlFS, "html/report.html"))
+
+// HTMLViewer renders a self-contained HTML report.
+// CSS and JavaScript are embedded in the report, so the report can be opened without network access.
+// HTML5 allows omitting the end tags of body and html elements, so sections are written one by one.
+type HTMLViewer struct {
+	stdout io.Writer
+	err    error
+}
+
+func NewHTML(stdout io.Writer) *HTMLViewer {
+	return &HTMLViewer{
+		stdout: stdout,
+	}
+}
+
+// Err returns the first error which occurred while rendering the report.
+func (v *HTMLViewer) Err() error {
+	return v.err
+}
+
+func (v *HTMLViewer) render(name string, data any) {
+	if v.err != nil {
+		return
+	}
+	if err := htmlTemplate.ExecuteTemplate(v.stdout, name, data); err != nil {
+		v.err = fmt.Errorf("render a HTML report: %w", slogerr.With(err, "template", name))
+	}
+}
+
+type htmlHeader struct {
+	Arg     *HeaderArg
+	Version string
+	Config  []*htmlConfigItem
+}
+
+type htmlConfigItem struct {
+	Name   string
+	Values []string
+}
+
+func (v *HTMLViewer) ShowHeader(arg *HeaderArg) {
+	version := arg.Version
+	if version == "" {
+		version = unknownVersion
+	}
+	header := &htmlHeader{
+		Arg:     arg,
+		Version: version,
+	}
+	if arg.Config != nil {
+		header.Config = getHTMLConfigItems(arg.Config)
+	}
+	v.render("header", header)
+}
+
+func getHTMLConfigItems(cfg *config.Config) []*htmlConfigItem {
+	items := []*htmlConfigItem{}
+	if len(cfg.JobNames) > 0 {
+		item := &htmlConfigItem{Name: "Job Names"}
+		for _, re := range cfg.JobNames {
+			item.Values = append(item.Values, re.String())
+		}
+		items = append(items, item)
+	}
+	if len(cfg.ExcludedJobNames) > 0 {
+		item := &htmlConfigItem{Name: "Excluded Job Names"}
+		for _, re := range cfg.ExcludedJobNames {
+			item.Values = append(item.Values, re.String())
+		}
+		items = append(items, item)
+	}
+	if len(cfg.JobNameMappings) > 0 {
+		item := &htmlConfigItem{Name: "Job Name Mappings"}
+		for re, name := range cfg.JobNameMappings {
+			item.Values = append(item.Values, re.String()+" => "+name)
+		}
+		sort.Strings(item.Values)
+		items = append(items, item)
+	}
+	return items
+}
+
+type htmlJob struct {
+	Job      *collector.Job
+	Duration time.Duration
+	Slow     bool
+	Bar      *Bar
+	Steps    []*htmlStep
+}
+
+type htmlStep struct {
+	Step *Step
+	// Status is the status of the step which isn't completed yet
+	Status string
+	Slow   bool
+	Bar    *Bar
+	Groups []*htmlGroup
+}
+
+type htmlGroup struct {
+	Name     string
+	Duration time.Duration
+	Slow     bool
+	Bar      *Bar
+}
+
+func newHTMLJob(j *collector.Job, threshold time.Duration) *htmlJob {
+	job := &htmlJob{
+		Job:      j,
+		Duration: j.Duration(),
+		Slow:     j.Duration() >= threshold,
+	}
+	start := j.Job.GetStartedAt().Time
+	steps := JobSteps(j)
+	job.Steps = make([]*htmlStep, len(steps))
+	for i, step := range steps {
+		groups := make([]*htmlGroup, len(step.Groups))
+		for k, group := range step.Groups {
+			groups[k] = &htmlGroup{
+				Name:     group.Name,
+				Duration: group.Duration(),
+				Slow:     group.Duration() >= threshold,
+				Bar:      NewBar(group.StartTime(), group.EndTime(), step.StartTime, step.Duration()),
+			}
+		}
+		var status string
+		if s := j.Job.Steps[i]; s.GetStatus() != "completed" {
+			status = s.GetStatus()
+		}
+		job.Steps[i] = &htmlStep{
+			Step:   step,
+			Status: status,
+			Slow:   status == "" && step.Duration() >= threshold,
+			Bar:    NewBar(step.StartTime, step.EndTime, start, job.Duration),
+			Groups: groups,
+		}
+	}
+	return job
+}
+
+func (v *HTMLViewer) ShowJob(job *collector.Job, threshold time.Duration) {
+	v.render("job", newHTMLJob(job, threshold))
+}
+
+type htmlGroups struct {
+	Groups      []*htmlGroup
+	MaxDuration time.Duration
+}
+
+func (v *HTMLViewer) ShowGroups(groups []*parser.Group, threshold time.Duration) {
+	data := &htmlGroups{
+		Groups: make([]*htmlGroup, 0, len(groups)),
+	}
+	for _, group := range groups {
+		if group.Duration() < threshold {
+			continue
+		}
+		data.Groups = append(data.Groups, &htmlGroup{
+			Name:     group.Name,
+			Duration: group.Duration(),
+			Slow:     true,
+		})
+		if group.Duration() > data.MaxDuration {
+			data.MaxDuration = group.Duration()
+		}
+	}
+	sort.Slice(data.Groups, func(i, j int) bool {
+		return data.Groups[i].Duration > data.Groups[j].Duration
+	})
+	v.render("groups", data)
+}
+
+type htmlRun struct {
+	Run         *collector.WorkflowRun
+	Jobs        []*htmlJob
+	PartialJobs []*collector.Job
+}
+
+func (v *HTMLViewer) ShowRun(run *collector.WorkflowRun, threshold time.Duration) {
+	jobs := make([]*collector.Job, 0, len(run.Jobs))
+	var start, end time.Time
+	for _, job := range run.Jobs {
+		if job.Job.GetStatus() != "completed" || job.Job.GetConclusion() == "skipped" {
+			continue
+		}
+		jobs = append(jobs, job)
+		if startedAt := job.Job.GetStartedAt().Time; start.IsZero() || startedAt.Before(start) {
+			start = startedAt
+		}
+		if completedAt := job.Job.GetCompletedAt().Time; completedAt.After(end) {
+			end = completedAt
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].Job.GetStartedAt().Before(jobs[j].Job.GetStartedAt().Time)
+	})
+	data := &htmlRun{
+		Run:         run,
+		Jobs:        make([]*htmlJob, len(jobs)),
+		PartialJobs: PartialJobs(run),
+	}
+	for i, job := range jobs {
+		j := newHTMLJob(job, threshold)
+		j.Bar = NewBar(job.Job.GetStartedAt().Time, job.Job.GetCompletedAt().Time, start, end.Sub(start))
+		data.Jobs[i] = j
+	}
+	v.render("run", data)
+}
+
+type htmlRuns struct {
+	Jobs        []*JobMetric
+	Threshold   time.Duration
+	MaxDuration time.Duration
+}
+
+func (v *HTMLViewer) ShowRuns(runs []*collector.WorkflowRun, threshold time.Duration) {
+	data := &htmlRuns{
+		Jobs:      getSlowJobs(AggregateRuns(runs), threshold),
+		Threshold: threshold,
+	}
+	for _, jm := range data.Jobs {
+		if jm.Metric.Avg > data.MaxDuration {
+			data.MaxDuration = jm.Metric.Avg
+		}
+	}
+	v.render("runs", data)
+}
+
+type htmlActions struct {
+	Actions     []*ActionMetric
+	MaxDuration time.Duration
+}
+
+func (v *HTMLViewer) ShowActions(runs []*collector.WorkflowRun, threshold time.Duration) {
+	data := &htmlActions{
+		Actions: getActionMetrics(runs, threshold),
+	}
+	for _, am := range data.Actions {
+		if am.Metric.Sum > data.MaxDuration {
+			data.MaxDuration = am.Metric.Sum
+		}
+	}
+	v.render("actions", data)
+}
+
+func (v *HTMLViewer) ShowTrend(runs []*collector.WorkflowRun, threshold time.Duration, bucket, stat string) {
+	v.render("trend", getTrend(runs, threshold, bucket, stat))
+}
+
+func (v *HTMLViewer) ShowChangePoints(cps []*ChangePoint) {
+	v.render("change-points", cps)
+}
+
+type htmlRetries struct {
+	*Retries
+	LostPercent float64
+}
+
+func (v *HTMLViewer) ShowAttempts(runs, attempts []*collector.WorkflowRun) {
+	retries := getRetries(runs, attempts)
+	v.render("attempts", &htmlRetries{
+		Retries:     retries,
+		LostPercent: retries.LostRate() * 100, //nolint:mnd
+	})
+}
+
+func (v *HTMLViewer) ShowConclusions(runs []*collector.WorkflowRun, threshold time.Duration) {
+	v.render("conclusions", getJobConclusions(runs, threshold))
+}
+
+func (v *HTMLViewer) ShowHangs(runs []*collector.WorkflowRun, threshold time.Duration) {
+	v.render("hangs", getHangGroups(runs, threshold))
+}
+
+func (v *HTMLViewer) ShowLogGroups(jobs []*collector.Job, threshold time.Duration) {
+	v.render("log-groups", getJobLogGroups(jobs, threshold))
+}
+
+type htmlCost struct {
+	Cost          *Cost
+	Jobs          []*JobCost
+	Steps         []*StepCost
+	MonthlyRatio  float64
+	UnknownLabels string
+}
+
+func (v *HTMLViewer) ShowCost(runs []*collector.WorkflowRun, threshold time.Duration, cfg *config.Config) {
+	cost := getCost(runs, cfg)
+	jobs := cost.sortedJobs()
+	v.render("cost", &htmlCost{
+		Cost:          cost,
+		Jobs:          jobs,
+		Steps:         getSlowStepCosts(jobs, threshold),
+		MonthlyRatio:  cost.monthlyRatio(),
+		UnknownLabels: strings.Join(slices.Sorted(maps.Keys(cost.UnknownLabels)), ", "),
+	})
+}
@@ -0,0 +1,11 @@
+// Expand or collapse all job and step trees.
+document.addEventListener("DOMContentLoaded", () => {
+  document.querySelectorAll("[data-toggle]").forEach((button) => {
+    button.addEventListener("click", () => {
+      const open = button.dataset.toggle === "open";
+      document.querySelectorAll("details").forEach((d) => {
+        d.open = open;
+      });
+    });
+  });
+});
@@ -0,0 +1,174 @@
+package view
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/config"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/parser"
+)
+
+// testJobName includes characters which must be escaped in HTML, CSV, and TSV.
+const testJobName = `build <script>, "a"	b`
+
+// newTestRuns returns workflow runs for tests of viewers.
+// The last run times out, and logs of the second job of the first run have gone.
+func newTestRuns() []*collector.WorkflowRun {
+	start := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	str := func(s string) *string { return &s }
+	runs := make([]*collector.WorkflowRun, 3)
+	for i := range runs {
+		runStart := start.Add(time.Duration(i) * 24 * time.Hour)
+		ts := func(d time.Duration) *github.Timestamp {
+			return &github.Timestamp{Time: runStart.Add(d)}
+		}
+		id := int64(i + 1)
+		attempt := 1
+		conclusion := "success"
+		lastLine := "ok"
+		if i == len(runs)-1 {
+			conclusion = "failure"
+			lastLine = "##[error]The job running on runner foo has exceeded the maximum execution time of 10 minutes."
+		}
+		jobDuration := time.Duration(i+1) * 10 * time.Minute
+		job := &collector.Job{
+			Job: &github.WorkflowJob{
+				ID:          &id,
+				Name:        str(testJobName),
+				Status:      str("completed"),
+				Conclusion:  str(conclusion),
+				Labels:      []string{"ubuntu-latest"},
+				StartedAt:   ts(0),
+				CompletedAt: ts(jobDuration),
+				Steps: []*github.TaskStep{
+					{Name: str("Set up job"), StartedAt: ts(0), CompletedAt: ts(time.Minute), Conclusion: str("success")},
+					{Name: str("Run make <test>"), StartedAt: ts(time.Minute), CompletedAt: ts(jobDuration), Conclusion: str(conclusion)},
+				},
+			},
+			Groups: []*parser.Group{
+				{Name: "Run make <test>", Lines: []*parser.Line{
+					{Timestamp: ts(time.Minute).Time, Content: "make test"},
+					{Timestamp: ts(jobDuration - time.Second).Time, Content: lastLine},
+				}},
+			},
+			NormalizedName: testJobName,
+		}
+		run := &collector.WorkflowRun{
+			Run: &github.WorkflowRun{
+				ID:           &id,
+				RunAttempt:   &attempt,
+				Name:         str("test"),
+				Status:       str("completed"),
+				Conclusion:   str(conclusion),
+				CreatedAt:    ts(0),
+				RunStartedAt: ts(0),
+				UpdatedAt:    ts(jobDuration),
+			},
+			Jobs: []*collector.Job{job},
+		}
+		runs[i] = run
+	}
+	runs[0].Jobs = append(runs[0].Jobs, &collector.Job{
+		Job: &github.WorkflowJob{
+			Name:       str("lint"),
+			Status:     str("completed"),
+			Conclusion: str("success"),
+		},
+		NormalizedName: "lint",
+		LogHasGone:     true,
+	})
+	return runs
+}
+
+func TestHTMLViewer(t *testing.T) {
+	t.Parallel()
+	runs := newTestRuns()
+	// templates included by other templates
+	partials := map[string]struct{}{"report": {}, "report.html": {}, "job-body": {}, "log-has-gone": {}, "trend-series": {}}
+	tests := map[string]func(v *HTMLViewer){
+		"header": func(v *HTMLViewer) {
+			v.ShowHeader(&HeaderArg{
+				Version:   "v1.0.0",
+				Repo:      "suzuki-shunsuke/ghaperf",
+				Now:       time.Date(2025, 10, 5, 0, 0, 0, 0, time.UTC),
+				Threshold: time.Second,
+				Config:    &config.Config{},
+			})
+		},
+		"job": func(v *HTMLViewer) {
+			v.ShowJob(runs[0].Jobs[0], time.Second)
+			v.ShowJob(runs[0].Jobs[1], time.Second)
+		},
+		"groups":        func(v *HTMLViewer) { v.ShowGroups(runs[0].Jobs[0].Groups, time.Second) },
+		"run":           func(v *HTMLViewer) { v.ShowRun(runs[0], time.Second) },
+		"runs":          func(v *HTMLViewer) { v.ShowRuns(runs, time.Second) },
+		"actions":       func(v *HTMLViewer) { v.ShowActions(runs, time.Second) },
+		"cost":          func(v *HTMLViewer) { v.ShowCost(runs, time.Second, &config.Config{}) },
+		"trend":         func(v *HTMLViewer) { v.ShowTrend(runs, time.Second, BucketDay, TrendStatAvg) },
+		"change-points": func(v *HTMLViewer) { v.ShowChangePoints(GetChangePoints(runs, time.Second)) },
+		"attempts":      func(v *HTMLViewer) { v.ShowAttempts(runs, runs[:1]) },
+		"conclusions":   func(v *HTMLViewer) { v.ShowConclusions(runs, time.Second) },
+		"hangs":         func(v *HTMLViewer) { v.ShowHangs(runs, time.Second) },
+		"log-groups":    func(v *HTMLViewer) { v.ShowLogGroups(runs[0].Jobs, time.Second) },
+	}
+	for _, tpl := range htmlTemplate.Templates() {
+		if _, ok := partials[tpl.Name()]; ok {
+			continue
+		}
+		if _, ok := tests[tpl.Name()]; !ok {
+			t.Errorf("the template %s isn't tested", tpl.Name())
+		}
+	}
+	for name, show := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			buf := &bytes.Buffer{}
+			v := NewHTML(buf)
+			show(v)
+			if err := v.Err(); err != nil {
+				t.Fatal(err)
+			}
+			report := buf.String()
+			if report == "" {
+				t.Fatal("nothing is rendered")
+			}
+			if name == "job" && !strings.Contains(report, "build &lt;script&gt;") {
+				t.Errorf("the escaped job name isn't rendered:\n%s", report)
+			}
+			for _, s := range []string{"<script>,", "make <test>"} {
+				if strings.Contains(report, s) {
+					t.Errorf("%q isn't escaped", s)
+				}
+			}
+		})
+	}
+}
+
+func TestNewBar(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		start, end time.Time
+		d          time.Duration
+		want       Bar
+	}{
+		{name: "middle", start: start.Add(25 * time.Second), end: start.Add(75 * time.Second), d: 100 * time.Second, want: Bar{Offset: 25, Width: 50}},
+		{name: "clamped", start: start.Add(-10 * time.Second), end: start.Add(110 * time.Second), d: 100 * time.Second, want: Bar{Offset: 0, Width: 100}},
+		{name: "empty timeline", start: start, end: start.Add(time.Second)},
+		{name: "not started", end: start, d: time.Minute},
+		{name: "end before start", start: start.Add(time.Second), end: start, d: time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := NewBar(tt.start, tt.end, start, tt.d); *got != tt.want {
+				t.Errorf("NewBar() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
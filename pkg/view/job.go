@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
 )
 
 const unknownVersion = "unknown"
@@ -19,7 +20,8 @@ func (v *Viewer) ShowJob(j *collector.Job, threshold time.Duration) { //nolint:f
 
 	allStepsDuration := time.Duration(0)
 	for _, step := range job.Steps {
-		allStepsDuration += step.GetCompletedAt().Sub(step.GetStartedAt().Time)
+		d, _ := collector.StepDuration(step)
+		allStepsDuration += d
 	}
 
 	slowGroups := getSlowGroups(j.Groups, threshold)
@@ -40,18 +42,25 @@ func (v *Viewer) ShowJob(j *collector.Job, threshold time.Duration) { //nolint:f
 	fmt.Fprintf(v.stdout, "<tr><td>Job Conclusion</td><td>%s</td></tr>\n", job.GetConclusion())
 	fmt.Fprintf(v.stdout, "<tr><td>Job Duration</td><td>%s</td></tr>\n", j.Duration())
 
-	if len(job.Steps) != 0 {
-		firstStepStartedAt := job.Steps[0].GetStartedAt().Time
-		lastStepCompletedAt := job.Steps[len(job.Steps)-1].GetCompletedAt().Time
-
+	// Setup and cleanup durations are unknown until the job completes
+	if firstStepStartedAt, lastStepCompletedAt, ok := completedStepsSpan(job.Steps); ok && !j.Partial {
 		fmt.Fprintf(v.stdout, "<tr><td>All Steps Duration</td><td>%s</td></tr>\n", allStepsDuration.Round(time.Second))
-		fmt.Fprintf(v.stdout, "<tr><td>Setup Job Duration</td><td>%s</td></tr>\n", firstStepStartedAt.Sub(job.StartedAt.Time).Round(time.Second))
+		fmt.Fprintf(v.stdout, "<tr><td>Setup Job Duration</td><td>%s</td></tr>\n", firstStepStartedAt.Sub(job.GetStartedAt().Time).Round(time.Second))
 		fmt.Fprintf(v.stdout, "<tr><td>Cleanup Job Duration</td><td>%s</td></tr>\n", job.GetCompletedAt().Sub(lastStepCompletedAt).Round(time.Second))
 		fmt.Fprintf(v.stdout, "<tr><td>Steps Overhead</td><td>%s</td></tr>\n", (lastStepCompletedAt.Sub(firstStepStartedAt) - allStepsDuration).Round(time.Second))
 	}
 
 	fmt.Fprintf(v.stdout, "</table>\n\n")
 
+	if j.Partial {
+		fmt.Fprintln(v.stdout, "> [!NOTE]")
+		fmt.Fprintln(v.stdout, "> The job isn't completed yet, so the report is partial.")
+		for _, step := range incompleteSteps(job.Steps) {
+			fmt.Fprintf(v.stdout, "> - %s: %s\n", step.GetName(), step.GetStatus())
+		}
+		fmt.Fprintln(v.stdout)
+	}
+
 	if j.LogHasGone {
 		v.ShowLogHasGone()
 		return
@@ -74,6 +83,32 @@ func (v *Viewer) ShowJob(j *collector.Job, threshold time.Duration) { //nolint:f
 	}
 }
 
+// completedStepsSpan returns the start time of the first completed step and the end time of the last completed step.
+func completedStepsSpan(steps []*github.TaskStep) (time.Time, time.Time, bool) {
+	var start, end time.Time
+	for _, step := range steps {
+		if _, ok := collector.StepDuration(step); !ok {
+			continue
+		}
+		if start.IsZero() {
+			start = step.GetStartedAt().Time
+		}
+		end = step.GetCompletedAt().Time
+	}
+	return start, end, !start.IsZero()
+}
+
+// incompleteSteps returns steps which aren't completed yet.
+func incompleteSteps(steps []*github.TaskStep) []*github.TaskStep {
+	var arr []*github.TaskStep
+	for _, step := range steps {
+		if step.GetStatus() != "completed" {
+			arr = append(arr, step)
+		}
+	}
+	return arr
+}
+
 func (v *Viewer) ShowLogHasGone() {
 	fmt.Fprintln(v.stdout, "> [!CAUTION]")
 	fmt.Fprintln(v.stdout, "> [Log has gone](https://docs.github.com/en/organizations/managing-organization-settings/configuring-the-retention-period-for-github-actions-artifacts-and-logs-in-your-organization)")
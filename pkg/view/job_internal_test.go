@@ -0,0 +1,102 @@
+package view
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/parser"
+)
+
+// newPartialJob returns a job in progress whose steps aren't completed yet.
+func newPartialJob() *collector.Job {
+	start := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	str := func(s string) *string { return &s }
+	ts := func(d time.Duration) *github.Timestamp {
+		return &github.Timestamp{Time: start.Add(d)}
+	}
+	id := int64(10)
+	return &collector.Job{
+		Job: &github.WorkflowJob{
+			ID:        &id,
+			Name:      str("test"),
+			Status:    str("in_progress"),
+			HTMLURL:   str("https://github.com/suzuki-shunsuke/ghaperf/actions/runs/1/job/10"),
+			StartedAt: ts(0),
+			Steps: []*github.TaskStep{
+				{Name: str("Set up job"), Status: str("completed"), Conclusion: str("success"), StartedAt: ts(0), CompletedAt: ts(time.Minute)},
+				{Name: str("Run make test"), Status: str("in_progress"), StartedAt: ts(time.Minute)},
+				{Name: str("Post Run actions/checkout"), Status: str("queued")},
+			},
+		},
+		Groups: []*parser.Group{
+			{Name: "Run make test", Lines: []*parser.Line{
+				{Timestamp: ts(time.Minute).Time},
+				{Timestamp: ts(2 * time.Minute).Time},
+			}},
+		},
+		NormalizedName: "test",
+		Partial:        true,
+	}
+}
+
+func TestViewer_ShowJob_partial(t *testing.T) {
+	t.Parallel()
+	buf := &bytes.Buffer{}
+	New(buf).ShowJob(newPartialJob(), 0)
+	out := buf.String()
+	for _, s := range []string{
+		"> The job isn't completed yet, so the report is partial.\n",
+		"> - Run make test: in_progress\n",
+		"> - Post Run actions/checkout: queued\n",
+		"1. 1m0s: Set up job\n",
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("the report should contain %q: %s", s, out)
+		}
+	}
+	for _, s := range []string{"Cleanup Job Duration", "Run make test\n"} {
+		if strings.Contains(out, s) {
+			t.Errorf("the report shouldn't contain %q: %s", s, out)
+		}
+	}
+}
+
+func TestHTMLViewer_ShowJob_partial(t *testing.T) {
+	t.Parallel()
+	buf := &bytes.Buffer{}
+	v := NewHTML(buf)
+	v.ShowJob(newPartialJob(), 0)
+	if err := v.Err(); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, s := range []string{
+		"The job isn't completed yet, so the report is partial.",
+		"<summary>1m0s: Set up job</summary>",
+		"<summary>in_progress: Run make test</summary>",
+		"<details>\n<summary>queued: Post Run actions/checkout</summary>",
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("the report should contain %q: %s", s, out)
+		}
+	}
+}
+
+func TestViewer_ShowRun_partial(t *testing.T) {
+	t.Parallel()
+	runs := newTestRuns()
+	run := runs[0]
+	run.Jobs = append(run.Jobs, newPartialJob())
+	buf := &bytes.Buffer{}
+	New(buf).ShowRun(run, 0)
+	out := buf.String()
+	want := "## Jobs in progress\nThese jobs aren't completed yet, so they are excluded from the analysis.\n" +
+		"- [test](https://github.com/suzuki-shunsuke/ghaperf/actions/runs/1/job/10): in_progress (started at 2025-10-01T00:00:00Z)\n"
+	if !strings.HasSuffix(out, want) {
+		t.Errorf("the report should end with jobs in progress: %s", out)
+	}
+}
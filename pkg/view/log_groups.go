@@ -0,0 +1,96 @@
+package view
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+)
+
+type JobLogGroups struct {
+	Name string
+	// Logs is the number of log files of the job
+	Logs   int
+	Groups []*LogGroupMetric
+}
+
+type LogGroupMetric struct {
+	Name      string
+	Metric    *Metric
+	P50       time.Duration
+	P90       time.Duration
+	durations []time.Duration
+}
+
+// getJobLogGroups aggregates durations of log groups of multiple log files by the normalized job name and the group name.
+// Each log group is a sample even if groups have the same name in a log file.
+func getJobLogGroups(jobs []*collector.Job, threshold time.Duration) []*JobLogGroups {
+	jobM := map[string]*JobLogGroups{}
+	groupM := map[string]map[string]*LogGroupMetric{}
+	for _, job := range jobs {
+		jlg, ok := jobM[job.NormalizedName]
+		if !ok {
+			jlg = &JobLogGroups{Name: job.NormalizedName}
+			jobM[job.NormalizedName] = jlg
+			groupM[job.NormalizedName] = map[string]*LogGroupMetric{}
+		}
+		jlg.Logs++
+		for _, group := range job.Groups {
+			if group.Name == "" {
+				// lines before the first group
+				continue
+			}
+			gm, ok := groupM[job.NormalizedName][group.Name]
+			if !ok {
+				gm = &LogGroupMetric{
+					Name:   group.Name,
+					Metric: &Metric{},
+				}
+				groupM[job.NormalizedName][group.Name] = gm
+			}
+			gm.Metric.Add(group.Duration())
+			gm.durations = append(gm.durations, group.Duration())
+		}
+	}
+	arr := make([]*JobLogGroups, 0, len(jobM))
+	for name, jlg := range jobM {
+		for _, gm := range groupM[name] {
+			if gm.Metric.Avg < threshold {
+				continue
+			}
+			gm.P50 = Percentile(gm.durations, 50) //nolint:mnd
+			gm.P90 = Percentile(gm.durations, 90) //nolint:mnd
+			jlg.Groups = append(jlg.Groups, gm)
+		}
+		if len(jlg.Groups) == 0 {
+			continue
+		}
+		sort.Slice(jlg.Groups, func(i, j int) bool {
+			return jlg.Groups[i].Metric.Sum > jlg.Groups[j].Metric.Sum
+		})
+		arr = append(arr, jlg)
+	}
+	sort.Slice(arr, func(i, j int) bool {
+		return arr[i].Name < arr[j].Name
+	})
+	return arr
+}
+
+func (v *Viewer) ShowLogGroups(jobs []*collector.Job, threshold time.Duration) {
+	arr := getJobLogGroups(jobs, threshold)
+	fmt.Fprintln(v.stdout, "## Slow log groups")
+	if len(arr) == 0 {
+		fmt.Fprintln(v.stdout, "No slow log group is found")
+		return
+	}
+	for _, jlg := range arr {
+		fmt.Fprintf(v.stdout, "### Job: %s (log files: %d)\n", jlg.Name, jlg.Logs)
+		fmt.Fprintln(v.stdout, "Log Group | Count | Average | p50 | p90")
+		fmt.Fprintln(v.stdout, "--- | --- | --- | --- | ---")
+		for _, gm := range jlg.Groups {
+			fmt.Fprintf(v.stdout, "%s | %d | %s | %s | %s\n", escapeTableCell(gm.Name), gm.Metric.Count, gm.Metric.Avg.Round(time.Second), gm.P50.Round(time.Second), gm.P90.Round(time.Second))
+		}
+		fmt.Fprintln(v.stdout)
+	}
+}
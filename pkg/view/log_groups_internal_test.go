@@ -0,0 +1,72 @@
+package view
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/parser"
+)
+
+func TestGetJobLogGroups(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2025, 10, 29, 12, 0, 0, 0, time.UTC)
+	newGroup := func(name string, d time.Duration) *parser.Group {
+		return &parser.Group{
+			Name:  name,
+			Lines: []*parser.Line{{Timestamp: start}, {Timestamp: start.Add(d)}},
+		}
+	}
+	newJob := func(name string, groups ...*parser.Group) *collector.Job {
+		return &collector.Job{
+			NormalizedName: name,
+			Groups:         append([]*parser.Group{newGroup("", time.Minute)}, groups...),
+		}
+	}
+	jobs := []*collector.Job{
+		newJob("test", newGroup("go test", 10*time.Second), newGroup("setup", time.Second)),
+		newJob("test", newGroup("go test", 20*time.Second)),
+		newJob("test", newGroup("go test", 60*time.Second)),
+		newJob("lint", newGroup("golangci-lint", time.Second)),
+	}
+	arr := getJobLogGroups(jobs, 5*time.Second)
+	if len(arr) != 1 {
+		t.Fatalf("the number of jobs = %d, want 1", len(arr))
+	}
+	jlg := arr[0]
+	if jlg.Name != "test" || jlg.Logs != 3 {
+		t.Errorf("job = %s (%d logs), want test (3 logs)", jlg.Name, jlg.Logs)
+	}
+	// unnamed groups and fast groups are excluded
+	if len(jlg.Groups) != 1 {
+		t.Fatalf("the number of groups = %d, want 1", len(jlg.Groups))
+	}
+	gm := jlg.Groups[0]
+	if gm.Name != "go test" || gm.Metric.Count != 3 || gm.Metric.Avg != 30*time.Second || gm.P50 != 20*time.Second || gm.P90 != 60*time.Second {
+		t.Errorf("group = %s count=%d avg=%s p50=%s p90=%s, want go test count=3 avg=30s p50=20s p90=1m0s", gm.Name, gm.Metric.Count, gm.Metric.Avg, gm.P50, gm.P90)
+	}
+}
+
+func TestViewer_ShowLogGroups(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2025, 10, 29, 12, 0, 0, 0, time.UTC)
+	jobs := []*collector.Job{
+		{
+			NormalizedName: "test",
+			Groups: []*parser.Group{
+				{
+					Name:  "Run cat x | grep y",
+					Lines: []*parser.Line{{Timestamp: start}, {Timestamp: start.Add(time.Minute)}},
+				},
+			},
+		},
+	}
+	buf := &bytes.Buffer{}
+	New(buf).ShowLogGroups(jobs, time.Second)
+	exp := "Run cat x \\| grep y | 1 | 1m0s | 1m0s | 1m0s\n"
+	if out := buf.String(); !strings.Contains(out, exp) {
+		t.Errorf("the output doesn't contain %q\n%s", exp, out)
+	}
+}
@@ -0,0 +1,106 @@
+package view
+
+import (
+	"time"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/parser"
+)
+
+const (
+	SampleKindJob   = "job"
+	SampleKindStep  = "step"
+	SampleKindGroup = "group"
+)
+
+// Sample is a duration sample of a job, a step, or a log group.
+type Sample struct {
+	Kind              string        `json:"kind"`
+	RunID             int64         `json:"run_id,omitempty"`
+	RunAttempt        int64         `json:"run_attempt,omitempty"`
+	WorkflowName      string        `json:"workflow_name,omitempty"`
+	Branch            string        `json:"branch,omitempty"`
+	JobID             int64         `json:"job_id,omitempty"`
+	JobName           string        `json:"job_name,omitempty"`
+	NormalizedJobName string        `json:"normalized_job_name,omitempty"`
+	RunnerName        string        `json:"runner_name,omitempty"`
+	Conclusion        string        `json:"conclusion,omitempty"`
+	StepNumber        int64         `json:"step_number,omitempty"`
+	StepName          string        `json:"step_name,omitempty"`
+	GroupName         string        `json:"group_name,omitempty"`
+	StartedAt         time.Time     `json:"started_at"`
+	CompletedAt       time.Time     `json:"completed_at"`
+	Duration          time.Duration `json:"duration"`
+}
+
+// ListSamples returns samples of jobs, steps, and log groups of the workflow run.
+// Like ShowRuns, jobs which aren't completed or are skipped are excluded.
+func ListSamples(run *collector.WorkflowRun) []*Sample {
+	samples := []*Sample{}
+	for _, job := range run.Jobs {
+		samples = append(samples, listJobSamples(job)...)
+	}
+	return samples
+}
+
+func listJobSamples(job *collector.Job) []*Sample {
+	if job.Job.GetStatus() != "completed" {
+		return nil
+	}
+	if job.Job.GetConclusion() == "skipped" {
+		return nil
+	}
+	j := job.Job
+	jobSample := &Sample{
+		Kind:              SampleKindJob,
+		RunID:             j.GetRunID(),
+		RunAttempt:        j.GetRunAttempt(),
+		WorkflowName:      j.GetWorkflowName(),
+		Branch:            j.GetHeadBranch(),
+		JobID:             j.GetID(),
+		JobName:           j.GetName(),
+		NormalizedJobName: job.NormalizedName,
+		RunnerName:        j.GetRunnerName(),
+		Conclusion:        j.GetConclusion(),
+		StartedAt:         j.GetStartedAt().Time,
+		CompletedAt:       j.GetCompletedAt().Time,
+		Duration:          job.Duration(),
+	}
+	samples := []*Sample{jobSample}
+	for _, s := range j.Steps {
+		step := newStep(s, job.Groups)
+		stepSample := *jobSample
+		stepSample.Kind = SampleKindStep
+		stepSample.Conclusion = s.GetConclusion()
+		stepSample.StepNumber = s.GetNumber()
+		stepSample.StepName = step.Name
+		stepSample.StartedAt = step.StartTime
+		stepSample.CompletedAt = step.EndTime
+		stepSample.Duration = step.Duration()
+		samples = append(samples, &stepSample)
+		for _, group := range step.Groups {
+			groupSample := stepSample
+			groupSample.Kind = SampleKindGroup
+			groupSample.GroupName = group.Name
+			groupSample.StartedAt = group.StartTime()
+			groupSample.CompletedAt = group.EndTime()
+			groupSample.Duration = group.Duration()
+			samples = append(samples, &groupSample)
+		}
+	}
+	return samples
+}
+
+func listGroupSamples(groups []*parser.Group) []*Sample {
+	samples := make([]*Sample, len(groups))
+	for i, group := range groups {
+		samples[i] = &Sample{
+			Kind:        SampleKindGroup,
+			GroupName:   group.Name,
+			StartedAt:   group.StartTime(),
+			CompletedAt: group.EndTime(),
+			Duration:    group.Duration(),
+		}
+	}
+	return samples
+}
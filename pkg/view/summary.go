@@ -0,0 +1,104 @@
+package view
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+)
+
+// Summary is the summary of workflow runs for step outputs of GitHub Actions.
+type Summary struct {
+	// TotalDuration is the average duration from the start of the first job to the end of the last job
+	TotalDuration time.Duration
+	// SlowestStep is the slowest step by the average duration in the format "<job> > <step>"
+	SlowestStep         string
+	SlowestStepDuration time.Duration
+}
+
+func Summarize(runs []*collector.WorkflowRun) *Summary {
+	summary := &Summary{}
+	m := &Metric{}
+	for _, run := range runs {
+		if d := runSpan(run); d > 0 {
+			m.Add(d)
+		}
+	}
+	summary.TotalDuration = m.Avg
+	for _, jm := range AggregateRuns(runs) {
+		for _, sm := range jm.Steps {
+			if sm.Metric.Avg > summary.SlowestStepDuration {
+				summary.SlowestStep = jm.Name + " > " + sm.Name
+				summary.SlowestStepDuration = sm.Metric.Avg
+			}
+		}
+	}
+	return summary
+}
+
+const (
+	BudgetStatusWithin   = "within"
+	BudgetStatusExceeded = "exceeded"
+)
+
+// BudgetStatus returns whether the total duration is within the budget.
+func (s *Summary) BudgetStatus(budget time.Duration) string {
+	if s.TotalDuration > budget {
+		return BudgetStatusExceeded
+	}
+	return BudgetStatusWithin
+}
+
+// ShowStepSummary writes the report for the job summary of GitHub Actions.
+// The summary table comes first and the full report is collapsed.
+func (s *Summary) ShowStepSummary(w io.Writer, budget time.Duration, report string) error {
+	header := "| Total Duration | Slowest Step |"
+	sep := "| --- | --- |"
+	row := fmt.Sprintf("| %s | %s |", s.TotalDuration.Round(time.Second), escapeTableCell(s.slowestStep()))
+	if budget > 0 {
+		header += " Budget |"
+		sep += " --- |"
+		row += fmt.Sprintf(" %s (%s) |", s.BudgetStatus(budget), budget)
+	}
+	if _, err := fmt.Fprintf(w, "## ghaperf\n\n%s\n%s\n%s\n\n<details>\n<summary>Report</summary>\n\n%s\n\n</details>\n\n",
+		header, sep, row, strings.TrimSpace(report)); err != nil {
+		return fmt.Errorf("write the job summary: %w", err)
+	}
+	return nil
+}
+
+func (s *Summary) slowestStep() string {
+	if s.SlowestStep == "" {
+		return "-"
+	}
+	return fmt.Sprintf("%s (%s)", s.SlowestStep, s.SlowestStepDuration.Round(time.Second))
+}
+
+var tableCellReplacer = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ", "|", `\|`) //nolint:gochecknoglobals
+
+// escapeTableCell escapes a value so that it doesn't break the Markdown table.
+func escapeTableCell(s string) string {
+	return tableCellReplacer.Replace(s)
+}
+
+// runSpan returns the duration from the start of the first job to the end of the last completed job.
+// Running jobs are ignored so that a workflow run can analyze itself.
+func runSpan(run *collector.WorkflowRun) time.Duration {
+	var start, end time.Time
+	for _, job := range run.Jobs {
+		if job.Job.GetStatus() != "completed" || job.Job.GetConclusion() == "skipped" {
+			continue
+		}
+		startedAt := job.Job.GetStartedAt().Time
+		completedAt := job.Job.GetCompletedAt().Time
+		if start.IsZero() || startedAt.Before(start) {
+			start = startedAt
+		}
+		if completedAt.After(end) {
+			end = completedAt
+		}
+	}
+	return end.Sub(start)
+}
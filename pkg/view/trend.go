@@ -0,0 +1,248 @@
+package view
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+)
+
+const (
+	TrendStatAvg = "avg"
+	TrendStatP90 = "p90"
+	// changes within this rate are considered as stable
+	stableChangeRate = 0.1
+)
+
+var sparkLevels = []rune("▁▂▃▄▅▆▇█") //nolint:gochecknoglobals
+
+type Trend struct {
+	Bucket  string
+	Stat    string
+	Buckets []time.Time
+	Jobs    []*JobTrend
+}
+
+// Period returns the description of the range of buckets.
+func (t *Trend) Period() string {
+	if len(t.Buckets) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d buckets from %s to %s", len(t.Buckets), t.Buckets[0].Format(time.DateOnly), t.Buckets[len(t.Buckets)-1].Format(time.DateOnly))
+}
+
+type JobTrend struct {
+	Name   string
+	Series *Series
+	Steps  []*StepTrend
+}
+
+type StepTrend struct {
+	Name   string
+	Series *Series
+}
+
+// Series is a time series of durations.
+// Values[i] is the statistic of samples in Trend.Buckets[i], or -1 if there is no sample.
+type Series struct {
+	Values []time.Duration
+	First  time.Duration
+	Last   time.Duration
+}
+
+func (s *Series) Sparkline() string {
+	minV, maxV := time.Duration(math.MaxInt64), time.Duration(0)
+	for _, v := range s.Values {
+		if v < 0 {
+			continue
+		}
+		minV = min(minV, v)
+		maxV = max(maxV, v)
+	}
+	buf := make([]rune, len(s.Values))
+	for i, v := range s.Values {
+		switch {
+		case v < 0:
+			buf[i] = ' '
+		case maxV == minV:
+			buf[i] = sparkLevels[len(sparkLevels)/2]
+		default:
+			buf[i] = sparkLevels[int(float64(v-minV)/float64(maxV-minV)*float64(len(sparkLevels)-1))]
+		}
+	}
+	return string(buf)
+}
+
+// Change returns the change rate from the first to the last bucket.
+func (s *Series) Change() float64 {
+	if s.First <= 0 {
+		return 0
+	}
+	return float64(s.Last-s.First) / float64(s.First)
+}
+
+// Direction returns the direction of change.
+func (s *Series) Direction() string {
+	change := s.Change()
+	switch {
+	case change > stableChangeRate:
+		return "↑ slower"
+	case change < -stableChangeRate:
+		return "↓ faster"
+	default:
+		return "→ stable"
+	}
+}
+
+type trendSamples struct {
+	jobs  map[string]map[time.Time][]time.Duration
+	steps map[string]map[string]map[time.Time][]time.Duration
+}
+
+func getTrend(runs []*collector.WorkflowRun, threshold time.Duration, bucket, stat string) *Trend {
+	samples := &trendSamples{
+		jobs:  map[string]map[time.Time][]time.Duration{},
+		steps: map[string]map[string]map[time.Time][]time.Duration{},
+	}
+	var first, last time.Time
+	for _, run := range runs {
+		start := BucketStart(run.Run.GetCreatedAt().Time, bucket)
+		if first.IsZero() || start.Before(first) {
+			first = start
+		}
+		if start.After(last) {
+			last = start
+		}
+		setTrendSamples(samples, run, start)
+	}
+	trend := &Trend{
+		Bucket:  bucket,
+		Stat:    stat,
+		Buckets: getBuckets(first, last, bucket),
+	}
+	for _, jm := range getSlowJobs(AggregateRuns(runs), threshold) {
+		jt := &JobTrend{
+			Name:   jm.Name,
+			Series: newSeries(trend.Buckets, samples.jobs[jm.Name], stat),
+		}
+		for _, sm := range jm.SortedSteps() {
+			if sm.Metric.Avg < threshold {
+				continue
+			}
+			jt.Steps = append(jt.Steps, &StepTrend{
+				Name:   sm.Name,
+				Series: newSeries(trend.Buckets, samples.steps[jm.Name][sm.Name], stat),
+			})
+		}
+		trend.Jobs = append(trend.Jobs, jt)
+	}
+	// jobs which changed most are shown first
+	sort.SliceStable(trend.Jobs, func(i, j int) bool {
+		return math.Abs(trend.Jobs[i].Series.Change()) > math.Abs(trend.Jobs[j].Series.Change())
+	})
+	return trend
+}
+
+func setTrendSamples(samples *trendSamples, run *collector.WorkflowRun, bucket time.Time) {
+	// Like ShowRuns, the slowest job is used for each normalized job name in a workflow run
+	slowestJobs := map[string]*collector.Job{}
+	for _, job := range run.Jobs {
+		if job.Job.GetStatus() != "completed" || job.Job.GetConclusion() == "skipped" {
+			continue
+		}
+		if slowestJobs[job.NormalizedName].Duration() < job.Duration() {
+			slowestJobs[job.NormalizedName] = job
+		}
+		steps, ok := samples.steps[job.NormalizedName]
+		if !ok {
+			steps = map[string]map[time.Time][]time.Duration{}
+			samples.steps[job.NormalizedName] = steps
+		}
+		for _, s := range job.Job.Steps {
+			d, ok := collector.StepDuration(s)
+			if !ok {
+				continue
+			}
+			if _, ok := steps[s.GetName()]; !ok {
+				steps[s.GetName()] = map[time.Time][]time.Duration{}
+			}
+			steps[s.GetName()][bucket] = append(steps[s.GetName()][bucket], d)
+		}
+	}
+	for name, job := range slowestJobs {
+		if _, ok := samples.jobs[name]; !ok {
+			samples.jobs[name] = map[time.Time][]time.Duration{}
+		}
+		samples.jobs[name][bucket] = append(samples.jobs[name][bucket], job.Duration())
+	}
+}
+
+func getBuckets(first, last time.Time, bucket string) []time.Time {
+	if first.IsZero() {
+		return nil
+	}
+	days := 1
+	if bucket == BucketWeek {
+		days = 7
+	}
+	buckets := []time.Time{}
+	for t := first; !t.After(last); t = t.AddDate(0, 0, days) {
+		buckets = append(buckets, t)
+	}
+	return buckets
+}
+
+func newSeries(buckets []time.Time, samples map[time.Time][]time.Duration, stat string) *Series {
+	series := &Series{
+		Values: make([]time.Duration, len(buckets)),
+	}
+	for i, b := range buckets {
+		ds := samples[b]
+		if len(ds) == 0 {
+			series.Values[i] = -1
+			continue
+		}
+		v := statDuration(ds, stat)
+		series.Values[i] = v
+		if series.First == 0 {
+			series.First = v
+		}
+		series.Last = v
+	}
+	return series
+}
+
+func statDuration(ds []time.Duration, stat string) time.Duration {
+	if stat == TrendStatP90 {
+		return Percentile(ds, 90) //nolint:mnd
+	}
+	var sum time.Duration
+	for _, d := range ds {
+		sum += d
+	}
+	return sum / time.Duration(len(ds))
+}
+
+func formatChange(s *Series) string {
+	return fmt.Sprintf("%s → %s (%s %+.0f%%)", s.First.Round(time.Second), s.Last.Round(time.Second), s.Direction(), s.Change()*100) //nolint:mnd
+}
+
+func (v *Viewer) ShowTrend(runs []*collector.WorkflowRun, threshold time.Duration, bucket, stat string) {
+	trend := getTrend(runs, threshold, bucket, stat)
+	fmt.Fprintf(v.stdout, "## Trend (%s, %s)\n", trend.Bucket, trend.Stat)
+	if len(trend.Jobs) == 0 {
+		fmt.Fprintln(v.stdout, "There is no slow job")
+		return
+	}
+	fmt.Fprintf(v.stdout, "%s\n\n", trend.Period())
+	for _, jt := range trend.Jobs {
+		fmt.Fprintf(v.stdout, "### Job: %s\n", jt.Name)
+		fmt.Fprintf(v.stdout, "`%s` %s\n\n", jt.Series.Sparkline(), formatChange(jt.Series))
+		for i, st := range jt.Steps {
+			fmt.Fprintf(v.stdout, "%d. `%s` %s: %s\n", i+1, st.Series.Sparkline(), formatChange(st.Series), strings.TrimSpace(st.Name))
+		}
+	}
+}
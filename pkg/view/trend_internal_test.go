@@ -0,0 +1,110 @@
+package view
+
+import (
+	"testing"
+	"time"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+)
+
+func TestSeries(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		series    *Series
+		sparkline string
+		direction string
+	}{
+		{
+			name: "slower",
+			series: &Series{
+				Values: []time.Duration{time.Minute, -1, 2 * time.Minute, 3 * time.Minute},
+				First:  time.Minute,
+				Last:   3 * time.Minute,
+			},
+			sparkline: "▁ ▄█",
+			direction: "↑ slower",
+		},
+		{
+			name: "faster",
+			series: &Series{
+				Values: []time.Duration{2 * time.Minute, time.Minute},
+				First:  2 * time.Minute,
+				Last:   time.Minute,
+			},
+			sparkline: "█▁",
+			direction: "↓ faster",
+		},
+		{
+			name: "stable",
+			series: &Series{
+				Values: []time.Duration{time.Minute, time.Minute},
+				First:  time.Minute,
+				Last:   time.Minute,
+			},
+			sparkline: "▅▅",
+			direction: "→ stable",
+		},
+		{
+			name: "missing buckets",
+			series: &Series{
+				Values: []time.Duration{-1, -1},
+			},
+			sparkline: "  ",
+			direction: "→ stable",
+		},
+		{
+			name:      "empty",
+			series:    &Series{},
+			sparkline: "",
+			direction: "→ stable",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if s := tt.series.Sparkline(); s != tt.sparkline {
+				t.Errorf("Sparkline() = %q, want %q", s, tt.sparkline)
+			}
+			if d := tt.series.Direction(); d != tt.direction {
+				t.Errorf("Direction() = %q, want %q", d, tt.direction)
+			}
+		})
+	}
+}
+
+func TestSetTrendSamples(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	str := func(s string) *string { return &s }
+	run := &collector.WorkflowRun{
+		Jobs: []*collector.Job{
+			{
+				Job: &github.WorkflowJob{
+					Status:      str("completed"),
+					Conclusion:  str("success"),
+					StartedAt:   &github.Timestamp{Time: start},
+					CompletedAt: &github.Timestamp{Time: start.Add(time.Minute)},
+					Steps: []*github.TaskStep{
+						{Name: str("test"), StartedAt: &github.Timestamp{Time: start}, CompletedAt: &github.Timestamp{Time: start.Add(time.Minute)}},
+						// skipped steps don't have started_at
+						{Name: str("skipped"), Conclusion: str("skipped")},
+					},
+				},
+				NormalizedName: "test",
+			},
+		},
+	}
+	samples := &trendSamples{
+		jobs:  map[string]map[time.Time][]time.Duration{},
+		steps: map[string]map[string]map[time.Time][]time.Duration{},
+	}
+	setTrendSamples(samples, run, start)
+	if d := samples.steps["test"]["test"][start]; len(d) != 1 || d[0] != time.Minute {
+		t.Errorf("samples of the step = %v, want [1m0s]", d)
+	}
+	if _, ok := samples.steps["test"]["skipped"]; ok {
+		t.Error("the skipped step should be ignored")
+	}
+}
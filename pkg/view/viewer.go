@@ -5,6 +5,7 @@ import (
 	"sort"
 	"time"
 
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
 	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
 	"github.com/suzuki-shunsuke/ghaperf/pkg/parser"
 )
@@ -41,8 +42,18 @@ func (s *Step) Duration() time.Duration {
 	return s.duration
 }
 
+// Contain adds the group to the step if the group belongs to the step.
+// Logs don't have step boundaries, so a group belongs to the step containing the center time of the group.
+// If the group has the step name of `gh run view --log`, the step name is compared instead.
+// Then the time is also compared inclusively because steps with the same name can exist.
 func (s *Step) Contain(group *parser.Group) {
 	centerTime := group.StartTime().Add(group.Duration() / 2) //nolint:mnd
+	if group.Step != "" {
+		if group.Step == s.Name && !centerTime.Before(s.StartTime) && !centerTime.After(s.EndTime) {
+			s.Groups = append(s.Groups, group)
+		}
+		return
+	}
 	if s.StartTime.Before(centerTime) && s.EndTime.After(centerTime) {
 		// The group is contained in the step
 		s.Groups = append(s.Groups, group)
@@ -50,18 +61,22 @@ func (s *Step) Contain(group *parser.Group) {
 	}
 }
 
+// JobSteps returns steps of the job with log groups belonging to them.
+func JobSteps(job *collector.Job) []*Step {
+	steps := make([]*Step, len(job.Job.Steps))
+	for i, s := range job.Job.Steps {
+		steps[i] = newStep(s, job.Groups)
+	}
+	return steps
+}
+
 func getSlowSteps(steps []*github.TaskStep, threshold time.Duration) []*Step {
 	slowSteps := make([]*Step, 0, len(steps))
 	for _, s := range steps {
-		step := &Step{
-			Name:      s.GetName(),
-			StartTime: s.StartedAt.Time,
-			EndTime:   s.CompletedAt.Time,
-		}
-		if step.Duration() < threshold {
+		if d, ok := collector.StepDuration(s); !ok || d < threshold {
 			continue
 		}
-		slowSteps = append(slowSteps, step)
+		slowSteps = append(slowSteps, newStep(s, nil))
 	}
 	return slowSteps
 }
@@ -0,0 +1,132 @@
+package view
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/parser"
+)
+
+func TestStep_Contain(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2025, 10, 25, 13, 0, 0, 0, time.UTC)
+	newGroup := func(step string, startSec, endSec int) *parser.Group {
+		return &parser.Group{
+			Name: "group",
+			Step: step,
+			Lines: []*parser.Line{
+				{Timestamp: start.Add(time.Duration(startSec) * time.Second)},
+				{Timestamp: start.Add(time.Duration(endSec) * time.Second)},
+			},
+		}
+	}
+	tests := []struct {
+		name  string
+		group *parser.Group
+		want  bool
+	}{
+		{
+			name:  "center time",
+			group: newGroup("", 10, 20),
+			want:  true,
+		},
+		{
+			name:  "center time at the boundary",
+			group: newGroup("", 30, 30),
+		},
+		{
+			name:  "step name at the boundary",
+			group: newGroup("Run make test", 30, 30),
+			want:  true,
+		},
+		{
+			name:  "other step",
+			group: newGroup("Run make build", 10, 20),
+		},
+		{
+			name:  "step with the same name",
+			group: newGroup("Run make test", 40, 50),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			step := &Step{
+				Name:      "Run make test",
+				StartTime: start,
+				EndTime:   start.Add(30 * time.Second),
+			}
+			step.Contain(tt.group)
+			if got := len(step.Groups) == 1; got != tt.want {
+				t.Errorf("contained = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// newIncompleteSteps returns steps including ones without started_at or completed_at.
+func newIncompleteSteps() []*github.TaskStep {
+	start := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	str := func(s string) *string { return &s }
+	ts := func(d time.Duration) *github.Timestamp {
+		return &github.Timestamp{Time: start.Add(d)}
+	}
+	return []*github.TaskStep{
+		{Name: str("checkout"), Status: str("completed"), StartedAt: ts(0), CompletedAt: ts(time.Minute)},
+		{Name: str("test"), Status: str("completed"), StartedAt: ts(time.Minute), CompletedAt: ts(4 * time.Minute)},
+		{Name: str("build"), Status: str("in_progress"), StartedAt: ts(4 * time.Minute)},
+		{Name: str("deploy"), Status: str("queued")},
+		{Name: str("notify"), Status: str("completed"), CompletedAt: ts(5 * time.Minute)},
+	}
+}
+
+func TestGetSlowSteps(t *testing.T) {
+	t.Parallel()
+	steps := getSlowSteps(newIncompleteSteps(), 0)
+	got := map[string]time.Duration{}
+	for _, step := range steps {
+		got[step.Name] = step.Duration()
+	}
+	want := map[string]time.Duration{"checkout": time.Minute, "test": 3 * time.Minute}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestAggregateRuns_incompleteSteps(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	str := func(s string) *string { return &s }
+	runs := []*collector.WorkflowRun{
+		{
+			Jobs: []*collector.Job{
+				{
+					Job: &github.WorkflowJob{
+						Name:        str("build"),
+						Status:      str("completed"),
+						Conclusion:  str("success"),
+						StartedAt:   &github.Timestamp{Time: start},
+						CompletedAt: &github.Timestamp{Time: start.Add(5 * time.Minute)},
+						Steps:       newIncompleteSteps(),
+					},
+					NormalizedName: "build",
+				},
+			},
+		},
+	}
+	jms := AggregateRuns(runs)
+	if len(jms) != 1 {
+		t.Fatalf("len(AggregateRuns()) = %d, want 1", len(jms))
+	}
+	got := map[string]time.Duration{}
+	for name, sm := range jms[0].Steps {
+		got[name] = sm.Metric.Sum
+	}
+	want := map[string]time.Duration{"checkout": time.Minute, "test": 3 * time.Minute}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Error(diff)
+	}
+}
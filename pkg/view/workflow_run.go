@@ -47,4 +47,34 @@ func (v *Viewer) ShowRun(run *collector.WorkflowRun, threshold time.Duration) {
 	for _, job := range arr {
 		v.ShowJob(job.Job, threshold)
 	}
+	v.showPartialJobs(PartialJobs(run))
+}
+
+// PartialJobs returns jobs which aren't completed yet.
+func PartialJobs(run *collector.WorkflowRun) []*collector.Job {
+	var jobs []*collector.Job
+	for _, job := range run.Jobs {
+		if job.Partial {
+			jobs = append(jobs, job)
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].Job.GetName() < jobs[j].Job.GetName()
+	})
+	return jobs
+}
+
+func (v *Viewer) showPartialJobs(jobs []*collector.Job) {
+	if len(jobs) == 0 {
+		return
+	}
+	fmt.Fprintln(v.stdout, "## Jobs in progress")
+	fmt.Fprintln(v.stdout, "These jobs aren't completed yet, so they are excluded from the analysis.")
+	for _, job := range jobs {
+		fmt.Fprintf(v.stdout, "- [%s](%s): %s", job.Job.GetName(), job.Job.GetHTMLURL(), job.Job.GetStatus())
+		if startedAt := job.Job.GetStartedAt(); !startedAt.IsZero() {
+			fmt.Fprintf(v.stdout, " (started at %s)", startedAt.Format(time.RFC3339))
+		}
+		fmt.Fprintln(v.stdout)
+	}
 }
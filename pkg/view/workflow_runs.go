@@ -45,14 +45,42 @@ type GroupMetric struct {
 	Metric *Metric
 }
 
-func (v *Viewer) ShowRuns(runs []*collector.WorkflowRun, threshold time.Duration) {
+// AggregateRuns aggregates durations of jobs, steps, and log groups by the normalized job name.
+func AggregateRuns(runs []*collector.WorkflowRun) []*JobMetric {
 	jobMetrics := map[string]*JobMetric{}
 	for _, run := range runs {
 		setMetricsByRun(jobMetrics, run)
 	}
-	jobArr := slices.Collect(maps.Values(jobMetrics))
+	return slices.Collect(maps.Values(jobMetrics))
+}
+
+// SortedSteps returns step metrics sorted by the total duration.
+func (jm *JobMetric) SortedSteps() []*StepMetric {
+	stepArr := slices.Collect(maps.Values(jm.Steps))
+	sort.Slice(stepArr, func(i, j int) bool {
+		return stepArr[i].Metric.Sum > stepArr[j].Metric.Sum
+	})
+	return stepArr
+}
+
+// SortedGroups returns log group metrics sorted by the total duration.
+func (sm *StepMetric) SortedGroups() []*GroupMetric {
+	groupArr := make([]*GroupMetric, 0, len(sm.Groups))
+	for groupName, m := range sm.Groups {
+		groupArr = append(groupArr, &GroupMetric{
+			Name:   groupName,
+			Metric: m,
+		})
+	}
+	sort.Slice(groupArr, func(i, j int) bool {
+		return groupArr[i].Metric.Sum > groupArr[j].Metric.Sum
+	})
+	return groupArr
+}
+
+func (v *Viewer) ShowRuns(runs []*collector.WorkflowRun, threshold time.Duration) {
 	// extract only slow jobs
-	slowJobs := getSlowJobs(jobArr, threshold)
+	slowJobs := getSlowJobs(AggregateRuns(runs), threshold)
 	if len(slowJobs) == 0 {
 		fmt.Fprintln(v.stdout, "There is no slow job")
 		return
@@ -66,10 +94,7 @@ func (v *Viewer) showJobMetric(jm *JobMetric, threshold time.Duration) {
 	if jm.Metric.Avg < threshold {
 		return
 	}
-	stepArr := slices.Collect(maps.Values(jm.Steps))
-	sort.Slice(stepArr, func(i, j int) bool {
-		return stepArr[i].Metric.Sum > stepArr[j].Metric.Sum
-	})
+	stepArr := jm.SortedSteps()
 	fmt.Fprintf(v.stdout, "## Job: %s\n", jm.Name)
 	slowestJobStrs := make([]string, len(jm.SlowestJobs))
 	for i, job := range jm.SlowestJobs {
@@ -100,17 +125,7 @@ func (v *Viewer) showSlowStepMetrics(slowSteps []*StepMetric, threshold time.Dur
 		if len(sm.Groups) <= 1 {
 			continue
 		}
-		groupArr := make([]*GroupMetric, 0, len(sm.Groups))
-		for groupName, m := range sm.Groups {
-			groupArr = append(groupArr, &GroupMetric{
-				Name:   groupName,
-				Metric: m,
-			})
-		}
-		sort.Slice(groupArr, func(i, j int) bool {
-			return groupArr[i].Metric.Sum > groupArr[j].Metric.Sum
-		})
-		for j, gm := range groupArr {
+		for j, gm := range sm.SortedGroups() {
 			if gm.Metric.Avg < threshold {
 				continue
 			}
@@ -163,17 +178,12 @@ func setMetricsByJob(jobMetrics map[string]*JobMetric, slowestJobs map[string]*c
 }
 
 func setJobMetric(jm *JobMetric, job *collector.Job, s *github.TaskStep) {
-	sm := initStepMetric(jm, s)
-	step := &Step{
-		Name:      s.GetName(),
-		StartTime: s.StartedAt.Time,
-		EndTime:   s.CompletedAt.Time,
+	if _, ok := collector.StepDuration(s); !ok {
+		return
 	}
+	sm := initStepMetric(jm, s)
+	step := newStep(s, job.Groups)
 	sm.Metric.Add(step.Duration())
-	// Extract groups belonging to the step
-	for _, group := range job.Groups {
-		step.Contain(group)
-	}
 	// Add step groups to the step metric
 	for _, group := range step.Groups {
 		m := initGroupMetric(sm, group)
@@ -181,6 +191,23 @@ func setJobMetric(jm *JobMetric, job *collector.Job, s *github.TaskStep) {
 	}
 }
 
+// newStep returns the step with log groups belonging to it.
+// Start and end times of steps which aren't completed are zero.
+func newStep(s *github.TaskStep, groups []*parser.Group) *Step {
+	step := &Step{
+		Name: s.GetName(),
+	}
+	if _, ok := collector.StepDuration(s); ok {
+		step.StartTime = s.GetStartedAt().Time
+		step.EndTime = s.GetCompletedAt().Time
+	}
+	// Extract groups belonging to the step
+	for _, group := range groups {
+		step.Contain(group)
+	}
+	return step
+}
+
 func initGroupMetric(sm *StepMetric, group *parser.Group) *Metric {
 	m, ok := sm.Groups[group.Name]
 	if ok {
@@ -0,0 +1,94 @@
+package watch
+
+import (
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/view"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+)
+
+// maxBaselineRuns is the maximum number of cached workflow runs to compute the baseline.
+const maxBaselineRuns = 50
+
+// Baseline is the p90 durations of jobs and steps in cached workflow runs of the same workflow.
+type Baseline struct {
+	Runs  int
+	Jobs  map[string]time.Duration
+	Steps map[string]map[string]time.Duration
+}
+
+func (b *Baseline) Step(jobName, stepName string) time.Duration {
+	return b.Steps[jobName][stepName]
+}
+
+func (w *Watcher) getBaseline(logger *slog.Logger, input *collector.Input, run *github.WorkflowRun) *Baseline {
+	cachedRuns, err := w.collector.ListCachedRuns(logger, input)
+	if err != nil {
+		slogerr.WithError(logger, err).Warn("list cached workflow runs")
+		return newBaseline(nil)
+	}
+	arr := make([]*github.WorkflowRun, 0, len(cachedRuns))
+	for _, r := range cachedRuns {
+		if r.GetWorkflowID() == run.GetWorkflowID() && r.GetID() != run.GetID() {
+			arr = append(arr, r)
+		}
+	}
+	sort.Slice(arr, func(i, j int) bool {
+		return arr[i].GetCreatedAt().After(arr[j].GetCreatedAt().Time)
+	})
+	if len(arr) > maxBaselineRuns {
+		arr = arr[:maxBaselineRuns]
+	}
+	runs := make([]*collector.WorkflowRun, 0, len(arr))
+	for _, r := range arr {
+		cachedRun, err := w.collector.GetCachedRun(logger, input, r.GetID(), r.GetRunAttempt())
+		if err != nil {
+			slogerr.WithError(logger, err).Warn("read a cached workflow run", "run_id", r.GetID())
+			continue
+		}
+		runs = append(runs, cachedRun)
+	}
+	return newBaseline(runs)
+}
+
+func newBaseline(runs []*collector.WorkflowRun) *Baseline {
+	jobs := map[string][]time.Duration{}
+	steps := map[string]map[string][]time.Duration{}
+	for _, run := range runs {
+		for _, job := range run.Jobs {
+			if job.Job.GetStatus() != statusCompleted || job.Job.GetConclusion() == "skipped" {
+				continue
+			}
+			jobs[job.NormalizedName] = append(jobs[job.NormalizedName], job.Duration())
+			if _, ok := steps[job.NormalizedName]; !ok {
+				steps[job.NormalizedName] = map[string][]time.Duration{}
+			}
+			for _, step := range job.Job.Steps {
+				d, ok := collector.StepDuration(step)
+				if !ok {
+					continue
+				}
+				steps[job.NormalizedName][step.GetName()] = append(steps[job.NormalizedName][step.GetName()], d)
+			}
+		}
+	}
+	baseline := &Baseline{
+		Runs:  len(runs),
+		Jobs:  make(map[string]time.Duration, len(jobs)),
+		Steps: make(map[string]map[string]time.Duration, len(steps)),
+	}
+	for name, ds := range jobs {
+		baseline.Jobs[name] = view.Percentile(ds, 90) //nolint:mnd
+	}
+	for jobName, m := range steps {
+		baseline.Steps[jobName] = make(map[string]time.Duration, len(m))
+		for stepName, ds := range m {
+			baseline.Steps[jobName][stepName] = view.Percentile(ds, 90) //nolint:mnd
+		}
+	}
+	return baseline
+}
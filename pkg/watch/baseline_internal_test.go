@@ -0,0 +1,50 @@
+package watch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+)
+
+func TestNewBaseline(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2025, 10, 29, 12, 0, 0, 0, time.UTC)
+	ts := func(d time.Duration) *github.Timestamp {
+		return &github.Timestamp{Time: start.Add(d)}
+	}
+	runs := []*collector.WorkflowRun{
+		{
+			Jobs: []*collector.Job{
+				{
+					Job: &github.WorkflowJob{
+						Name:        ptr("test"),
+						Status:      ptr("completed"),
+						Conclusion:  ptr("success"),
+						StartedAt:   ts(0),
+						CompletedAt: ts(5 * time.Minute),
+						Steps: []*github.TaskStep{
+							{Name: ptr("checkout"), Status: ptr("completed"), StartedAt: ts(0), CompletedAt: ts(time.Minute)},
+							// skipped steps may not have started_at
+							{Name: ptr("cache"), Status: ptr("completed"), Conclusion: ptr("skipped"), CompletedAt: ts(time.Minute)},
+							{Name: ptr("post"), Status: ptr("queued")},
+						},
+					},
+					NormalizedName: "test",
+				},
+			},
+		},
+	}
+	baseline := newBaseline(runs)
+	want := map[string]map[string]time.Duration{
+		"test": {"checkout": time.Minute},
+	}
+	if diff := cmp.Diff(want, baseline.Steps); diff != "" {
+		t.Error(diff)
+	}
+	if d := baseline.Jobs["test"]; d != 5*time.Minute {
+		t.Errorf("the baseline of the job = %s, want 5m", d)
+	}
+}
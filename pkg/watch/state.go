@@ -0,0 +1,174 @@
+package watch
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+)
+
+type State struct {
+	Run      *github.WorkflowRun
+	Elapsed  time.Duration
+	Jobs     []*JobState
+	Baseline *Baseline
+	Now      time.Time
+}
+
+type JobState struct {
+	Job     *github.WorkflowJob
+	Elapsed time.Duration
+	P90     time.Duration
+	// CurrentStep is the running step
+	CurrentStep        *github.TaskStep
+	CurrentStepElapsed time.Duration
+	CurrentStepP90     time.Duration
+	// SlowSteps are completed steps slower than the p90
+	SlowSteps []*SlowStep
+}
+
+type SlowStep struct {
+	Name     string
+	Duration time.Duration
+	P90      time.Duration
+}
+
+// Slow returns true if the job is slower than the p90.
+func (js *JobState) Slow() bool {
+	return js.P90 > 0 && js.Elapsed > js.P90
+}
+
+// CurrentStepSlow returns true if the running step is slower than the p90.
+func (js *JobState) CurrentStepSlow() bool {
+	return js.CurrentStepP90 > 0 && js.CurrentStepElapsed > js.CurrentStepP90
+}
+
+func newState(input *collector.Input, run *github.WorkflowRun, jobs []*github.WorkflowJob, baseline *Baseline, now time.Time) *State {
+	state := &State{
+		Run:      run,
+		Baseline: baseline,
+		Now:      now,
+	}
+	if startedAt := run.GetRunStartedAt().Time; !startedAt.IsZero() {
+		end := now
+		if run.GetStatus() == statusCompleted {
+			end = run.GetUpdatedAt().Time
+		}
+		state.Elapsed = end.Sub(startedAt)
+	}
+	for _, job := range jobs {
+		if !input.Config.Include(job.GetName()) {
+			continue
+		}
+		state.Jobs = append(state.Jobs, newJobState(input.Config.NormalizeJobName(job.GetName()), job, baseline, now))
+	}
+	sort.SliceStable(state.Jobs, func(i, j int) bool {
+		a, b := state.Jobs[i].Job.GetStartedAt().Time, state.Jobs[j].Job.GetStartedAt().Time
+		if a.IsZero() != b.IsZero() {
+			return !a.IsZero()
+		}
+		return a.Before(b)
+	})
+	return state
+}
+
+func newJobState(name string, job *github.WorkflowJob, baseline *Baseline, now time.Time) *JobState {
+	js := &JobState{
+		Job: job,
+		P90: baseline.Jobs[name],
+	}
+	if startedAt := job.GetStartedAt().Time; !startedAt.IsZero() && job.GetStatus() != "queued" {
+		end := now
+		if job.GetStatus() == statusCompleted {
+			end = job.GetCompletedAt().Time
+		}
+		js.Elapsed = end.Sub(startedAt)
+	}
+	for _, step := range job.Steps {
+		p90 := baseline.Step(name, step.GetName())
+		switch step.GetStatus() {
+		case "in_progress":
+			js.CurrentStep = step
+			js.CurrentStepElapsed = now.Sub(step.GetStartedAt().Time)
+			js.CurrentStepP90 = p90
+		case statusCompleted:
+			d, ok := collector.StepDuration(step)
+			if ok && p90 > 0 && d > p90 {
+				js.SlowSteps = append(js.SlowSteps, &SlowStep{
+					Name:     step.GetName(),
+					Duration: d,
+					P90:      p90,
+				})
+			}
+		}
+	}
+	return js
+}
+
+func render(w io.Writer, state *State, interval time.Duration) {
+	run := state.Run
+	fmt.Fprintf(w, "Workflow Run: %s #%d (%s)\n", run.GetName(), run.GetRunNumber(), run.GetHTMLURL())
+	status := run.GetStatus()
+	if run.GetConclusion() != "" {
+		status += " / " + run.GetConclusion()
+	}
+	fmt.Fprintf(w, "Status: %s, Elapsed: %s\n", status, state.Elapsed.Round(time.Second))
+	if state.Baseline.Runs == 0 {
+		fmt.Fprintln(w, "Baseline: no cached workflow run. Analyze workflow runs of the workflow to compare durations with p90")
+	} else {
+		fmt.Fprintf(w, "Baseline: p90 of %d cached workflow runs\n", state.Baseline.Runs)
+	}
+	fmt.Fprintln(w)
+	for _, js := range state.Jobs {
+		renderJob(w, js)
+	}
+	fmt.Fprintln(w)
+	if run.GetStatus() != statusCompleted {
+		fmt.Fprintf(w, "Updated at %s (every %s)\n", state.Now.Format(time.TimeOnly), interval)
+	}
+}
+
+func renderJob(w io.Writer, js *JobState) {
+	job := js.Job
+	status := job.GetStatus()
+	if job.GetConclusion() != "" {
+		status = job.GetConclusion()
+	}
+	fmt.Fprintf(w, "%s %s (%s", jobMark(js), job.GetName(), status)
+	if js.Elapsed > 0 {
+		fmt.Fprintf(w, ", %s", js.Elapsed.Round(time.Second))
+	}
+	if js.P90 > 0 {
+		fmt.Fprintf(w, ", p90 %s", js.P90.Round(time.Second))
+	}
+	fmt.Fprintln(w, ")")
+	if js.CurrentStep != nil {
+		fmt.Fprintf(w, "    ▶ %s: %s", js.CurrentStep.GetName(), js.CurrentStepElapsed.Round(time.Second))
+		if js.CurrentStepP90 > 0 {
+			fmt.Fprintf(w, " (p90 %s)", js.CurrentStepP90.Round(time.Second))
+		}
+		if js.CurrentStepSlow() {
+			fmt.Fprint(w, " SLOWER THAN P90")
+		}
+		fmt.Fprintln(w)
+	}
+	for _, step := range js.SlowSteps {
+		fmt.Fprintf(w, "    ! %s: %s (p90 %s)\n", step.Name, step.Duration.Round(time.Second), step.P90.Round(time.Second))
+	}
+}
+
+func jobMark(js *JobState) string {
+	switch {
+	case js.Slow() || js.CurrentStepSlow():
+		return "!"
+	case js.Job.GetStatus() == statusCompleted:
+		return "✓"
+	case js.Job.GetStatus() == "in_progress":
+		return "▶"
+	default:
+		return "…"
+	}
+}
@@ -0,0 +1,56 @@
+package watch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestNewJobState(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2025, 10, 29, 12, 0, 0, 0, time.UTC)
+	ts := func(d time.Duration) *github.Timestamp {
+		return &github.Timestamp{Time: now.Add(-d)}
+	}
+	job := &github.WorkflowJob{
+		Name:      ptr("test"),
+		Status:    ptr("in_progress"),
+		StartedAt: ts(10 * time.Minute),
+		Steps: []*github.TaskStep{
+			{Name: ptr("checkout"), Status: ptr("completed"), StartedAt: ts(10 * time.Minute), CompletedAt: ts(8 * time.Minute)},
+			{Name: ptr("go test"), Status: ptr("in_progress"), StartedAt: ts(8 * time.Minute)},
+			{Name: ptr("post"), Status: ptr("queued")},
+		},
+	}
+	baseline := &Baseline{
+		Runs: 10,
+		Jobs: map[string]time.Duration{"test": 15 * time.Minute},
+		Steps: map[string]map[string]time.Duration{
+			"test": {
+				"checkout": time.Minute,
+				"go test":  5 * time.Minute,
+			},
+		},
+	}
+	js := newJobState("test", job, baseline, now)
+	if js.Elapsed != 10*time.Minute {
+		t.Errorf("Elapsed = %s, want 10m", js.Elapsed)
+	}
+	if js.Slow() {
+		t.Error("the job should not be slower than p90")
+	}
+	if js.CurrentStep.GetName() != "go test" || js.CurrentStepElapsed != 8*time.Minute {
+		t.Errorf("CurrentStep = %s (%s), want go test (8m)", js.CurrentStep.GetName(), js.CurrentStepElapsed)
+	}
+	if !js.CurrentStepSlow() {
+		t.Error("the current step should be slower than p90")
+	}
+	if len(js.SlowSteps) != 1 || js.SlowSteps[0].Name != "checkout" {
+		t.Errorf("SlowSteps = %+v, want checkout", js.SlowSteps)
+	}
+}
@@ -0,0 +1,98 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/suzuki-shunsuke/ghaperf/pkg/collector"
+	"github.com/suzuki-shunsuke/ghaperf/pkg/github"
+)
+
+const statusCompleted = "completed"
+
+var errInvalidInterval = errors.New("the polling interval must be greater than 0")
+
+// clearScreen moves the cursor to the top left and clears the terminal.
+const clearScreen = "\x1b[H\x1b[2J"
+
+type GitHub interface {
+	GetWorkflowRunByID(ctx context.Context, owner, repo string, runID int64, attempt int) (*github.WorkflowRun, error)
+	ListWorkflowJobs(ctx context.Context, owner, repo string, runID int64, attempt int) ([]*github.WorkflowJob, error)
+}
+
+type Collector interface {
+	ListCachedRuns(logger *slog.Logger, input *collector.Input) ([]*github.WorkflowRun, error)
+	GetCachedRun(logger *slog.Logger, input *collector.Input, runID int64, attempt int) (*collector.WorkflowRun, error)
+}
+
+type Watcher struct {
+	gh        GitHub
+	collector Collector
+	stdout    io.Writer
+	interval  time.Duration
+	tty       bool
+	now       func() time.Time
+}
+
+func New(gh GitHub, clt Collector, stdout io.Writer, interval time.Duration) *Watcher {
+	return &Watcher{
+		gh:        gh,
+		collector: clt,
+		stdout:    stdout,
+		interval:  interval,
+		tty:       isTerminal(stdout),
+		now:       time.Now,
+	}
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Watch polls the workflow run and its jobs until the workflow run completes.
+func (w *Watcher) Watch(ctx context.Context, logger *slog.Logger, input *collector.Input) error {
+	if w.interval <= 0 {
+		return errInvalidInterval
+	}
+	var baseline *Baseline
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		run, err := w.gh.GetWorkflowRunByID(ctx, input.RepoOwner, input.RepoName, input.RunID, input.AttemptNumber)
+		if err != nil {
+			return fmt.Errorf("get a workflow run: %w", err)
+		}
+		jobs, err := w.gh.ListWorkflowJobs(ctx, input.RepoOwner, input.RepoName, input.RunID, run.GetRunAttempt())
+		if err != nil {
+			return fmt.Errorf("list jobs of a workflow run: %w", err)
+		}
+		if baseline == nil {
+			baseline = w.getBaseline(logger, input, run)
+		}
+		if w.tty {
+			fmt.Fprint(w.stdout, clearScreen)
+		}
+		render(w.stdout, newState(input, run, jobs, baseline, w.now()), w.interval)
+		if run.GetStatus() == statusCompleted {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err() //nolint:wrapcheck
+		case <-ticker.C:
+		}
+	}
+}
@@ -20,12 +20,16 @@ func cacheHome(getEnv func(string) string, home string) string {
 	return filepath.Join(home, ".cache")
 }
 
+func RunsCacheDir(cacheDir, repoOwner, repoName string) string {
+	return filepath.Join(cacheDir, "runs", repoOwner, repoName)
+}
+
 func JobCache(cacheDir, repoOwner, repoName string, jobID int64) string {
 	return filepath.Join(cacheDir, "jobs", repoOwner, repoName, strconv.FormatInt(jobID, 10), "job.json")
 }
 
 func JobLogCache(jobCachePath string) string {
-	return filepath.Join(filepath.Dir(jobCachePath), "log.txt")
+	return filepath.Join(filepath.Dir(jobCachePath), "log.txt.gz")
 }
 
 func RunCache(cacheDir, repoOwner, repoName string, runID int64, attempt int) string {
@@ -45,3 +49,15 @@ func RunLogCache(cacheDir, repoOwner, repoName string, runID int64, attempt int)
 func RunLogCacheFile(cacheDir, repoOwner, repoName string, runID int64, attempt int) string {
 	return filepath.Join(cacheDir, "runs", repoOwner, repoName, strconv.FormatInt(runID, 10), strconv.Itoa(attempt), "cached.txt")
 }
+
+func JobsCacheDir(cacheDir, repoOwner, repoName string) string {
+	return filepath.Join(cacheDir, "jobs", repoOwner, repoName)
+}
+
+func IndexCacheDir(cacheDir, repoOwner, repoName string) string {
+	return filepath.Join(cacheDir, "index", repoOwner, repoName)
+}
+
+func RunIndexCache(cacheDir, repoOwner, repoName, workflow, key string) string {
+	return filepath.Join(cacheDir, "index", repoOwner, repoName, workflow, key+".json")
+}
@@ -0,0 +1,29 @@
+package xdg
+
+import (
+	"path/filepath"
+	"strconv"
+)
+
+const envXDGDataHome = "XDG_DATA_HOME"
+
+// DataDir returns the directory for persistent data.
+// Unlike the cache, data in this directory shouldn't be removed to free up disk space.
+func DataDir(getEnv func(string) string, home string) string {
+	return filepath.Join(dataHome(getEnv, home), "ghaperf")
+}
+
+func dataHome(getEnv func(string) string, home string) string {
+	if s := getEnv(envXDGDataHome); s != "" {
+		return s
+	}
+	return filepath.Join(home, ".local", "share")
+}
+
+func HistoryDir(dataDir, repoOwner, repoName string) string {
+	return filepath.Join(dataDir, "history", repoOwner, repoName)
+}
+
+func HistoryFile(dataDir, repoOwner, repoName string, runID int64, attempt int) string {
+	return filepath.Join(HistoryDir(dataDir, repoOwner, repoName), strconv.FormatInt(runID, 10)+"-"+strconv.Itoa(attempt)+".jsonl")
+}